@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"errors"
+	"flag"
 	"fmt"
 	"io/fs"
 	"log/slog"
@@ -11,7 +12,9 @@ import (
 	"strings"
 	"time"
 
+	"xiaoyuzhoufm-mcp/internal/auth"
 	"xiaoyuzhoufm-mcp/internal/server" // Import the server package
+	"xiaoyuzhoufm-mcp/internal/tools"
 	"xiaoyuzhoufm-mcp/internal/xyzclient"
 
 	"github.com/lmittmann/tint"
@@ -45,36 +48,148 @@ func main() {
 		interactiveLogin(tm) // Call the new combined interactiveLogin function
 		slog.Debug("Initialization complete. Token saved. Exiting.")
 		os.Exit(0)
-	} else {
-		// Default server mode
-		slog.Debug("MCP Server starting in default mode...")
+	} else if len(os.Args) > 1 && os.Args[1] == "logout" {
+		slog.Debug("Running in logout mode.")
 		tm, err := xyzclient.GetTokenManager()
 		if err != nil {
-			slog.Error("Failed to get token manager.", "error", err)
+			slog.Error("Failed to get token manager for logout.", "error", err)
+			fmt.Printf("Error initializing for logout: %v\n", err)
 			os.Exit(1)
 		}
-
-		userTokenPath, pathErr := xyzclient.GetUserTokenPath()
-		if pathErr != nil {
-			slog.Error("Failed to determine user token path.", "error", pathErr)
+		logout(tm)
+		os.Exit(0)
+	} else if len(os.Args) > 1 && os.Args[1] == "serve" {
+		slog.Debug("MCP Server starting in network serve mode...")
+		opts := parseServeFlags(os.Args[2:])
+		if opts.Tenants == nil {
+			loadUserToken()
+		}
+		if err := server.RunHTTPServer(opts); err != nil {
+			slog.Error("MCP HTTP Server exited with error.", "error", err)
 			os.Exit(1)
 		}
+	} else {
+		// Default server mode
+		slog.Debug("MCP Server starting in default mode...")
+		loadUserToken()
+		server.RunStdioServer(defaultPromptsDir())
+	}
+	slog.Debug("MCP Server closed.")
+}
+
+// loadUserToken loads the saved token into the token manager, exiting with a hint to run init
+// when it is missing or unreadable.
+func loadUserToken() {
+	tm, err := xyzclient.GetTokenManager()
+	if err != nil {
+		slog.Error("Failed to get token manager.", "error", err)
+		os.Exit(1)
+	}
+
+	userTokenPath, pathErr := xyzclient.GetUserTokenPath()
+	if pathErr != nil {
+		slog.Error("Failed to determine user token path.", "error", pathErr)
+		os.Exit(1)
+	}
+
+	slog.Debug("Attempting to load token from user path.", "path", userTokenPath)
+	if err := tm.LoadTokenFromPath(userTokenPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			slog.Error("Token file not found at user path. Please run './xiaoyuzhoufm-mcp init' first.", "path", userTokenPath)
+			fmt.Fprintln(os.Stderr, "Error: Token not found. Please run './xiaoyuzhoufm-mcp init' to login and create the token.")
+		} else {
+			slog.Error("Failed to load token from user path. The token file might be corrupted.", "path", userTokenPath, "error", err)
+			fmt.Fprintf(os.Stderr, "Error: Failed to load token from %s. It might be corrupted. Try running './xiaoyuzhoufm-mcp init' again.\n", userTokenPath)
+		}
+		os.Exit(1)
+	}
+	slog.Debug("Token loaded successfully from user path.")
+}
+
+// defaultPromptsDir returns the default prompt templates directory, or "" (built-in prompts
+// only) when it cannot be determined.
+func defaultPromptsDir() string {
+	dir, err := tools.GetDefaultPromptsDir()
+	if err != nil {
+		slog.Warn("Failed to determine prompts directory, using built-in prompts only.", "error", err)
+		return ""
+	}
+	return dir
+}
+
+// parseServeFlags parses the flags of the serve subcommand.
+func parseServeFlags(args []string) server.HTTPOptions {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	transport := flags.String("transport", server.TransportHTTP, "network transport: http (streamable HTTP) or sse")
+	addr := flags.String("addr", ":8080", "address to listen on")
+	basePath := flags.String("base-path", "/mcp", "path the MCP endpoint is mounted at")
+	allowedOrigins := flags.String("allowed-origins", "", "comma-separated browser origins to accept (default: localhost only)")
+	authConfigPath := flags.String("auth-config", "", "path to the API key / OAuth client config (default: ~/.mcp/xiaoyuzhoufm-mcp/auth.json)")
+	noAuth := flags.Bool("no-auth", false, "disable authentication; only use on a trusted network")
+	publicURL := flags.String("public-url", "", "externally visible base URL used in OAuth metadata (default: derived from --addr)")
+	multiTenant := flags.Bool("multi-tenant", false, "give each authenticated principal its own Xiaoyuzhou account")
+	tenantsDir := flags.String("tenants-dir", "", "directory of per-principal token files (default: ~/.mcp/xiaoyuzhoufm-mcp/tenants)")
+	promptsDir := flags.String("prompts-dir", "", "directory of prompt templates overriding or adding prompts (default: ~/.mcp/xiaoyuzhoufm-mcp/prompts)")
+	flags.Parse(args)
+
+	opts := server.HTTPOptions{
+		Transport:  *transport,
+		Addr:       *addr,
+		BasePath:   *basePath,
+		PublicURL:  *publicURL,
+		PromptsDir: *promptsDir,
+	}
+	if opts.PromptsDir == "" {
+		opts.PromptsDir = defaultPromptsDir()
+	}
+	for _, origin := range strings.Split(*allowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			opts.AllowedOrigins = append(opts.AllowedOrigins, origin)
+		}
+	}
 
-		slog.Debug("Attempting to load token from user path.", "path", userTokenPath)
-		if err := tm.LoadTokenFromPath(userTokenPath); err != nil {
-			if errors.Is(err, fs.ErrNotExist) {
-				slog.Error("Token file not found at user path. Please run './xiaoyuzhoufm-mcp init' first.", "path", userTokenPath)
-				fmt.Fprintln(os.Stderr, "Error: Token not found. Please run './xiaoyuzhoufm-mcp init' to login and create the token.")
-			} else {
-				slog.Error("Failed to load token from user path. The token file might be corrupted.", "path", userTokenPath, "error", err)
-				fmt.Fprintf(os.Stderr, "Error: Failed to load token from %s. It might be corrupted. Try running './xiaoyuzhoufm-mcp init' again.\n", userTokenPath)
+	if *multiTenant {
+		if *noAuth {
+			fmt.Fprintln(os.Stderr, "Error: --multi-tenant requires authentication and cannot be combined with --no-auth.")
+			os.Exit(1)
+		}
+		if *tenantsDir == "" {
+			defaultDir, err := xyzclient.GetDefaultTenantsDir()
+			if err != nil {
+				slog.Error("Failed to determine tenants directory.", "error", err)
+				os.Exit(1)
 			}
+			*tenantsDir = defaultDir
+		}
+		slog.Debug("Multi-tenant mode enabled.", "tenantsDir", *tenantsDir)
+		opts.Tenants = xyzclient.NewTenantStore(*tenantsDir)
+	}
+
+	if *noAuth {
+		return opts
+	}
+	if *authConfigPath == "" {
+		defaultPath, err := auth.GetDefaultConfigPath()
+		if err != nil {
+			slog.Error("Failed to determine auth config path.", "error", err)
 			os.Exit(1)
 		}
-		slog.Debug("Token loaded successfully from user path.")
-		server.RunStdioServer()
+		*authConfigPath = defaultPath
 	}
-	slog.Debug("MCP Server closed.")
+	authConfig, err := auth.LoadConfig(*authConfigPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			slog.Error("Auth config not found. Create it or pass --no-auth to serve without authentication.", "path", *authConfigPath)
+			fmt.Fprintf(os.Stderr, "Error: auth config not found at %s. See README for the format, or pass --no-auth on a trusted network.\n", *authConfigPath)
+		} else {
+			slog.Error("Failed to load auth config.", "path", *authConfigPath, "error", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	slog.Debug("Auth config loaded.", "path", *authConfigPath, "apiKeys", len(authConfig.APIKeys), "clients", len(authConfig.Clients))
+	opts.Auth = authConfig
+	return opts
 }
 
 // interactiveLogin handles the full interactive login process,
@@ -181,6 +296,40 @@ func interactiveLogin(tm *xyzclient.TokenManager) {
 	fmt.Println("Login successful and token saved!")
 }
 
+// logout revokes the stored session server-side and securely removes the local token file.
+func logout(tm *xyzclient.TokenManager) {
+	userTokenPath, pathErr := xyzclient.GetUserTokenPath()
+	if pathErr != nil {
+		slog.Error("Failed to determine user token path.", "error", pathErr)
+		fmt.Printf("Error determining token location: %v\n", pathErr)
+		os.Exit(1)
+	}
+
+	if err := tm.LoadTokenFromPath(userTokenPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Println("Not logged in: no token found. Nothing to do.")
+			return
+		}
+		// A corrupted token cannot be revoked, but it should still be removed.
+		slog.Warn("Failed to load token, wiping local file only.", "path", userTokenPath, "error", err)
+	}
+
+	if err := tm.Logout(userTokenPath); err != nil {
+		slog.Error("Logout did not complete cleanly.", "error", err)
+		if !errors.Is(err, xyzclient.ErrRevokeFailed) {
+			fmt.Printf("Error: %v\n", err)
+			fmt.Printf("The local token at %s may still exist. Please remove it manually.\n", userTokenPath)
+			os.Exit(1)
+		}
+		fmt.Printf("Warning: %v\n", err)
+		fmt.Printf("The local token at %s has been removed.\n", userTokenPath)
+		os.Exit(1)
+	}
+
+	slog.Debug("Logout complete.", "path", userTokenPath)
+	fmt.Println("Logged out. The local token and account caches have been removed.")
+}
+
 func isValidAreaCode(areaCode string) bool {
 	re := regexp.MustCompile(`^\+\d{1,3}$`)
 	return re.MatchString(areaCode)
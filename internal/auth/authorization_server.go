@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paths served by the built-in authorization server, relative to the issuer URL.
+const (
+	MetadataPath      = "/.well-known/oauth-authorization-server"
+	TokenEndpointPath = "/oauth/token"
+)
+
+const accessTokenBytes = 32
+
+// issuedToken is an OAuth access token handed out by the token endpoint.
+type issuedToken struct {
+	principal *Principal
+	expiresAt time.Time
+}
+
+// AuthorizationServer authenticates bearer tokens, which are either static API keys from the
+// config or access tokens it issued itself through the OAuth 2.1 client_credentials grant.
+// Issued tokens live in memory only, so clients request a new one after a restart.
+type AuthorizationServer struct {
+	issuer   string
+	tokenTTL time.Duration
+	apiKeys  map[[sha256.Size]byte]*Principal
+	clients  map[string]Client
+
+	mu     sync.Mutex
+	tokens map[[sha256.Size]byte]issuedToken
+}
+
+// NewAuthorizationServer creates an authorization server for the given config.
+// issuer is the externally visible base URL of the server, e.g. "https://mcp.example.com".
+func NewAuthorizationServer(issuer string, config *Config) *AuthorizationServer {
+	as := &AuthorizationServer{
+		issuer:   strings.TrimSuffix(issuer, "/"),
+		tokenTTL: config.tokenTTL(),
+		apiKeys:  make(map[[sha256.Size]byte]*Principal, len(config.APIKeys)),
+		clients:  make(map[string]Client, len(config.Clients)),
+		tokens:   make(map[[sha256.Size]byte]issuedToken),
+	}
+	for _, key := range config.APIKeys {
+		as.apiKeys[sha256.Sum256([]byte(key.Key))] = &Principal{Name: key.Principal, Method: "api_key", Scopes: key.Scopes}
+	}
+	for _, client := range config.Clients {
+		as.clients[client.ClientID] = client
+	}
+	return as
+}
+
+// Issuer returns the issuer URL advertised in the metadata.
+func (as *AuthorizationServer) Issuer() string {
+	return as.issuer
+}
+
+// Authenticate resolves a bearer token to its principal. Tokens are compared by their SHA-256
+// digest, so lookups do not leak the stored values through timing.
+func (as *AuthorizationServer) Authenticate(token string) (*Principal, bool) {
+	if token == "" {
+		return nil, false
+	}
+	digest := sha256.Sum256([]byte(token))
+	if principal, ok := as.apiKeys[digest]; ok {
+		return principal, true
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	issued, ok := as.tokens[digest]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(issued.expiresAt) {
+		delete(as.tokens, digest)
+		return nil, false
+	}
+	return issued.principal, true
+}
+
+// Register mounts the metadata and token endpoints on mux.
+func (as *AuthorizationServer) Register(mux *http.ServeMux) {
+	mux.HandleFunc(MetadataPath, as.handleMetadata)
+	mux.HandleFunc(TokenEndpointPath, as.handleToken)
+}
+
+// handleMetadata serves the RFC 8414 authorization server metadata.
+func (as *AuthorizationServer) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                as.issuer,
+		"token_endpoint":                        as.issuer + TokenEndpointPath,
+		"grant_types_supported":                 []string{"client_credentials"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"response_types_supported":              []string{},
+		"scopes_supported":                      SupportedScopes,
+	})
+}
+
+// handleToken implements the client_credentials grant of the token endpoint.
+func (as *AuthorizationServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+	if grantType := r.PostForm.Get("grant_type"); grantType != "client_credentials" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "only client_credentials is supported")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	client, known := as.clients[clientID]
+	if !known || subtle.ConstantTimeCompare([]byte(client.ClientSecret), []byte(clientSecret)) != 1 {
+		slog.Warn("OAuth token request with invalid client credentials.", "clientID", clientID, "remoteAddr", r.RemoteAddr)
+		w.Header().Set("WWW-Authenticate", `Basic realm="xiaoyuzhoufm-mcp"`)
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "client authentication failed")
+		return
+	}
+
+	scopes := client.Scopes
+	if requested := strings.Fields(r.PostForm.Get("scope")); len(requested) > 0 {
+		for _, scope := range requested {
+			if !slices.Contains(client.Scopes, scope) {
+				writeOAuthError(w, http.StatusBadRequest, "invalid_scope", "scope '"+scope+"' is not allowed for this client")
+				return
+			}
+		}
+		scopes = requested
+	}
+
+	token, err := as.issueToken(&Principal{Name: client.Principal, Method: "oauth", Scopes: scopes})
+	if err != nil {
+		slog.Error("Failed to issue OAuth access token.", "clientID", clientID, "error", err)
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "failed to issue token")
+		return
+	}
+
+	slog.Info("Issued OAuth access token.", "clientID", clientID, "principal", client.Principal, "scopes", scopes)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   int(as.tokenTTL.Seconds()),
+		"scope":        strings.Join(scopes, " "),
+	})
+}
+
+// issueToken creates a random access token for principal and drops expired ones.
+func (as *AuthorizationServer) issueToken(principal *Principal) (string, error) {
+	raw := make([]byte, accessTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now()
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	for digest, issued := range as.tokens {
+		if now.After(issued.expiresAt) {
+			delete(as.tokens, digest)
+		}
+	}
+	as.tokens[sha256.Sum256([]byte(token))] = issuedToken{principal: principal, expiresAt: now.Add(as.tokenTTL)}
+	return token, nil
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Warn("Failed to write JSON response.", "error", err)
+	}
+}
@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testAPIKey       = "api-key-0123456789"
+	testClientSecret = "client-secret-0123456789"
+)
+
+func newTestServer() *AuthorizationServer {
+	return NewAuthorizationServer("https://mcp.example.com/", &Config{
+		APIKeys: []APIKey{{Key: testAPIKey, Principal: "reader", Scopes: []string{ScopeRead}}},
+		Clients: []Client{{ClientID: "agent", ClientSecret: testClientSecret, Principal: "alice", Scopes: []string{ScopeRead, ScopeWrite}}},
+	})
+}
+
+// requestToken posts form to the token endpoint and decodes the JSON response.
+func requestToken(t *testing.T, as *AuthorizationServer, form url.Values, basicAuth bool) (int, map[string]interface{}) {
+	t.Helper()
+	if basicAuth {
+		form = cloneValues(form)
+		form.Del("client_id")
+		form.Del("client_secret")
+	}
+	r := httptest.NewRequest(http.MethodPost, TokenEndpointPath, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if basicAuth {
+		r.SetBasicAuth("agent", testClientSecret)
+	}
+	w := httptest.NewRecorder()
+	as.handleToken(w, r)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("token response is not JSON: %s", w.Body.String())
+	}
+	return w.Code, body
+}
+
+func cloneValues(values url.Values) url.Values {
+	clone := url.Values{}
+	for key, value := range values {
+		clone[key] = append([]string(nil), value...)
+	}
+	return clone
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	as := newTestServer()
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"configured key", testAPIKey, true},
+		{"empty", "", false},
+		{"prefix of the key", testAPIKey[:8], false},
+		{"unknown", "not-a-configured-key", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, ok := as.Authenticate(tt.token)
+			if ok != tt.ok {
+				t.Fatalf("Authenticate(%q) ok = %v, want %v", tt.token, ok, tt.ok)
+			}
+			if ok && (principal.Name != "reader" || principal.Method != "api_key" || !principal.HasScope(ScopeRead) || principal.HasScope(ScopeWrite)) {
+				t.Errorf("Authenticate(%q) = %+v", tt.token, principal)
+			}
+		})
+	}
+}
+
+func TestTokenEndpointIssuesTokens(t *testing.T) {
+	tests := []struct {
+		name       string
+		scope      string
+		basicAuth  bool
+		wantScopes []string
+	}{
+		{"all client scopes by default", "", false, []string{ScopeRead, ScopeWrite}},
+		{"requested subset", ScopeRead, false, []string{ScopeRead}},
+		{"basic authentication", ScopeWrite, true, []string{ScopeWrite}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := newTestServer()
+			form := url.Values{"grant_type": {"client_credentials"}, "client_id": {"agent"}, "client_secret": {testClientSecret}}
+			if tt.scope != "" {
+				form.Set("scope", tt.scope)
+			}
+			status, body := requestToken(t, as, form, tt.basicAuth)
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, body = %v", status, body)
+			}
+			if body["token_type"] != "Bearer" || body["expires_in"] != float64(defaultTokenTTL.Seconds()) {
+				t.Errorf("token response = %v", body)
+			}
+
+			token, _ := body["access_token"].(string)
+			principal, ok := as.Authenticate(token)
+			if !ok {
+				t.Fatalf("issued token %q is not accepted", token)
+			}
+			if principal.Name != "alice" || principal.Method != "oauth" || !reflect.DeepEqual(principal.Scopes, tt.wantScopes) {
+				t.Errorf("principal = %+v, want alice with scopes %v", principal, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestTokenEndpointRejects(t *testing.T) {
+	valid := url.Values{"grant_type": {"client_credentials"}, "client_id": {"agent"}, "client_secret": {testClientSecret}}
+	with := func(key, value string) url.Values {
+		form := cloneValues(valid)
+		form.Set(key, value)
+		return form
+	}
+	tests := []struct {
+		name       string
+		method     string
+		form       url.Values
+		wantStatus int
+		wantError  string
+	}{
+		{"GET", http.MethodGet, valid, http.StatusMethodNotAllowed, ""},
+		{"other grant type", http.MethodPost, with("grant_type", "authorization_code"), http.StatusBadRequest, "unsupported_grant_type"},
+		{"missing grant type", http.MethodPost, with("grant_type", ""), http.StatusBadRequest, "unsupported_grant_type"},
+		{"unknown client", http.MethodPost, with("client_id", "stranger"), http.StatusUnauthorized, "invalid_client"},
+		{"wrong secret", http.MethodPost, with("client_secret", "wrong-secret-0123456789"), http.StatusUnauthorized, "invalid_client"},
+		{"scope beyond the client", http.MethodPost, with("scope", "xyz:admin"), http.StatusBadRequest, "invalid_scope"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := newTestServer()
+			r := httptest.NewRequest(tt.method, TokenEndpointPath, strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			as.handleToken(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantError != "" && !strings.Contains(w.Body.String(), `"error":"`+tt.wantError+`"`) {
+				t.Errorf("body = %s, want error %s", w.Body.String(), tt.wantError)
+			}
+			if len(as.tokens) != 0 {
+				t.Errorf("a rejected request issued %d tokens", len(as.tokens))
+			}
+		})
+	}
+}
+
+func TestIssuedTokensExpire(t *testing.T) {
+	as := newTestServer()
+	expired, err := as.issueToken(&Principal{Name: "alice", Method: "oauth", Scopes: []string{ScopeRead}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(expired))
+	issued := as.tokens[digest]
+	issued.expiresAt = time.Now().Add(-time.Second)
+	as.tokens[digest] = issued
+
+	if _, ok := as.Authenticate(expired); ok {
+		t.Error("an expired token was accepted")
+	}
+	if _, ok := as.tokens[digest]; ok {
+		t.Error("an expired token was kept after Authenticate")
+	}
+
+	// Issuing a token also drops other expired ones.
+	stale, _ := as.issueToken(&Principal{Name: "alice"})
+	staleDigest := sha256.Sum256([]byte(stale))
+	issued = as.tokens[staleDigest]
+	issued.expiresAt = time.Now().Add(-time.Second)
+	as.tokens[staleDigest] = issued
+	fresh, _ := as.issueToken(&Principal{Name: "alice"})
+	if _, ok := as.tokens[staleDigest]; ok {
+		t.Error("issueToken kept an expired token")
+	}
+	if _, ok := as.Authenticate(fresh); !ok {
+		t.Error("a fresh token was rejected")
+	}
+}
+
+func TestConfigTokenTTL(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    time.Duration
+	}{
+		{0, defaultTokenTTL},
+		{-5, defaultTokenTTL},
+		{90, 90 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := (&Config{TokenTTLSeconds: tt.seconds}).tokenTTL(); got != tt.want {
+			t.Errorf("tokenTTL(%d) = %v, want %v", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	key := APIKey{Key: testAPIKey, Principal: "reader", Scopes: []string{ScopeRead}}
+	client := Client{ClientID: "agent", ClientSecret: testClientSecret, Principal: "alice", Scopes: []string{ScopeWrite}}
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{"valid", Config{APIKeys: []APIKey{key}, Clients: []Client{client}}, ""},
+		{"empty", Config{}, "at least one"},
+		{"short key", Config{APIKeys: []APIKey{{Key: "short", Principal: "reader", Scopes: []string{ScopeRead}}}}, "at least 16"},
+		{"key without principal", Config{APIKeys: []APIKey{{Key: testAPIKey, Scopes: []string{ScopeRead}}}}, "principal"},
+		{"key without scopes", Config{APIKeys: []APIKey{{Key: testAPIKey, Principal: "reader"}}}, "scopes cannot be empty"},
+		{"unsupported scope", Config{APIKeys: []APIKey{{Key: testAPIKey, Principal: "reader", Scopes: []string{"xyz:admin"}}}}, "unsupported scope"},
+		{"short client secret", Config{Clients: []Client{{ClientID: "agent", ClientSecret: "short", Principal: "alice", Scopes: []string{ScopeRead}}}}, "client_secret"},
+		{"client without principal", Config{Clients: []Client{{ClientID: "agent", ClientSecret: testClientSecret, Scopes: []string{ScopeRead}}}}, "principal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validate error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	configFileName  = "auth.json"
+	defaultTokenTTL = time.Hour
+)
+
+// APIKey is a static bearer token bound to a principal.
+type APIKey struct {
+	Key       string   `json:"key"`
+	Principal string   `json:"principal"`
+	Scopes    []string `json:"scopes"`
+}
+
+// Client is an OAuth client allowed to obtain access tokens with the client_credentials grant.
+type Client struct {
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Principal    string   `json:"principal"`
+	Scopes       []string `json:"scopes"` // Upper bound of the scopes the client may request
+}
+
+// Config is the authentication configuration of the HTTP transport.
+type Config struct {
+	APIKeys []APIKey `json:"api_keys"`
+	Clients []Client `json:"clients"`
+	// TokenTTLSeconds is the lifetime of issued OAuth access tokens; defaults to one hour.
+	TokenTTLSeconds int `json:"token_ttl_seconds,omitempty"`
+}
+
+// GetDefaultConfigPath returns the auth config path next to the user's token file.
+func GetDefaultConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".mcp", "xiaoyuzhoufm-mcp", configFileName), nil
+}
+
+// LoadConfig reads and validates the auth config at path. A missing file is reported as fs.ErrNotExist.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fs.ErrNotExist
+		}
+		return nil, fmt.Errorf("failed to read auth config %s: %w", path, err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal auth config %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid auth config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// tokenTTL returns the configured access token lifetime.
+func (c *Config) tokenTTL() time.Duration {
+	if c.TokenTTLSeconds <= 0 {
+		return defaultTokenTTL
+	}
+	return time.Duration(c.TokenTTLSeconds) * time.Second
+}
+
+func (c *Config) validate() error {
+	if len(c.APIKeys) == 0 && len(c.Clients) == 0 {
+		return fmt.Errorf("at least one api key or client must be configured")
+	}
+	for i, key := range c.APIKeys {
+		if len(key.Key) < 16 {
+			return fmt.Errorf("api_keys[%d]: key must be at least 16 characters", i)
+		}
+		if key.Principal == "" {
+			return fmt.Errorf("api_keys[%d]: principal cannot be empty", i)
+		}
+		if err := validateScopes(key.Scopes); err != nil {
+			return fmt.Errorf("api_keys[%d]: %w", i, err)
+		}
+	}
+	for i, client := range c.Clients {
+		if client.ClientID == "" || len(client.ClientSecret) < 16 {
+			return fmt.Errorf("clients[%d]: client_id is required and client_secret must be at least 16 characters", i)
+		}
+		if client.Principal == "" {
+			return fmt.Errorf("clients[%d]: principal cannot be empty", i)
+		}
+		if err := validateScopes(client.Scopes); err != nil {
+			return fmt.Errorf("clients[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("scopes cannot be empty")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(SupportedScopes, scope) {
+			return fmt.Errorf("unsupported scope %q", scope)
+		}
+	}
+	return nil
+}
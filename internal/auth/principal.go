@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Scopes granted to MCP clients. Read-only tools need ScopeRead; tools that change
+// account data (follow, favorite, pick, ...) need ScopeWrite.
+const (
+	ScopeRead  = "xyz:read"
+	ScopeWrite = "xyz:write"
+)
+
+// SupportedScopes lists every scope the authorization server can grant.
+var SupportedScopes = []string{ScopeRead, ScopeWrite}
+
+// Principal is the authenticated identity behind an HTTP request and its MCP session.
+type Principal struct {
+	Name   string   // Stable name from the auth config, shown in logs
+	Method string   // "api_key" or "oauth"
+	Scopes []string // Granted scopes
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, or nil when the request was
+// not authenticated (stdio mode, or HTTP mode with authentication disabled).
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return principal
+}
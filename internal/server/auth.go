@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"xiaoyuzhoufm-mcp/internal/auth"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withAuthentication requires a valid bearer token on every request and stores the
+// resolved principal in the request context, where the MCP tool layer picks it up.
+func withAuthentication(next http.Handler, as *auth.AuthorizationServer, resourceMetadataURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		principal, ok := as.Authenticate(strings.TrimSpace(token))
+		if !found || !ok {
+			slog.Warn("Rejected unauthenticated MCP request.", "remoteAddr", r.RemoteAddr, "path", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token", resource_metadata=%q`, resourceMetadataURL))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		slog.Debug("Authenticated MCP request.", "principal", principal.Name, "method", principal.Method, "path", r.URL.Path)
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// isWriteTool reports whether a tool may change account data. Only tools registered with
+// readTool are read-only; any other tool needs the write scope, so a tool that was never
+// classified is not exposed to read-only principals.
+func isWriteTool(tool mcp.Tool) bool {
+	readOnly := tool.Annotations.ReadOnlyHint
+	return readOnly == nil || !*readOnly
+}
+
+// filterToolsByScope hides tools the principal has no scope for. mcp-go applies tool filters
+// to tools/call as well, so a hidden tool cannot be invoked either. Unauthenticated contexts
+// (stdio, or HTTP with authentication disabled) see every tool.
+func filterToolsByScope(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil {
+		return tools
+	}
+	allowed := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		required := auth.ScopeRead
+		if isWriteTool(tool) {
+			required = auth.ScopeWrite
+		}
+		if principal.HasScope(required) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
+
+// sessionPrincipals ties each MCP session to the principal that opened it, so that a session
+// ID leaked to another client cannot be reused under different credentials.
+type sessionPrincipals struct {
+	mu       sync.Mutex
+	sessions map[string]string // Session ID -> principal name
+}
+
+// authorizationOptions returns the MCP server options that enforce scopes, bind sessions to
+// principals and log the principal of every session and tool call.
+func authorizationOptions() []server.ServerOption {
+	bindings := &sessionPrincipals{sessions: make(map[string]string)}
+
+	registerHooks := withHooks(func(hooks *server.Hooks) {
+		hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+			principal := auth.PrincipalFromContext(ctx)
+			if principal == nil {
+				return
+			}
+			bindings.mu.Lock()
+			bindings.sessions[session.SessionID()] = principal.Name
+			bindings.mu.Unlock()
+			slog.Info("MCP session started.", "session", session.SessionID(), "principal", principal.Name, "scopes", principal.Scopes)
+		})
+		hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+			bindings.mu.Lock()
+			principalName, ok := bindings.sessions[session.SessionID()]
+			delete(bindings.sessions, session.SessionID())
+			bindings.mu.Unlock()
+			if ok {
+				slog.Info("MCP session ended.", "session", session.SessionID(), "principal", principalName)
+			}
+		})
+	})
+
+	checkSession := func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			principal := auth.PrincipalFromContext(ctx)
+			if principal == nil {
+				return next(ctx, request)
+			}
+			sessionID := ""
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				sessionID = session.SessionID()
+				bindings.mu.Lock()
+				owner, bound := bindings.sessions[sessionID]
+				bindings.mu.Unlock()
+				if bound && owner != principal.Name {
+					slog.Warn("Rejected tool call on a session owned by another principal.", "session", sessionID, "principal", principal.Name, "owner", owner)
+					return mcp.NewToolResultError("错误: 当前会话属于其他身份，请重新建立连接。"), nil
+				}
+			}
+			slog.Info("MCP tool call.", "tool", request.Params.Name, "principal", principal.Name, "session", sessionID)
+			return next(ctx, request)
+		}
+	}
+
+	checkResourceScope := func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			principal := auth.PrincipalFromContext(ctx)
+			if principal == nil {
+				return next(ctx, request)
+			}
+			if !principal.HasScope(auth.ScopeRead) {
+				return nil, fmt.Errorf("principal %s lacks scope %s", principal.Name, auth.ScopeRead)
+			}
+			slog.Info("MCP resource read.", "uri", request.Params.URI, "principal", principal.Name)
+			return next(ctx, request)
+		}
+	}
+
+	checkPromptScope := func(next server.PromptHandlerFunc) server.PromptHandlerFunc {
+		return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			principal := auth.PrincipalFromContext(ctx)
+			if principal == nil {
+				return next(ctx, request)
+			}
+			if !principal.HasScope(auth.ScopeRead) {
+				return nil, fmt.Errorf("principal %s lacks scope %s", principal.Name, auth.ScopeRead)
+			}
+			slog.Info("MCP prompt rendered.", "prompt", request.Params.Name, "principal", principal.Name)
+			return next(ctx, request)
+		}
+	}
+
+	return []server.ServerOption{
+		registerHooks,
+		server.WithToolFilter(filterToolsByScope),
+		server.WithToolHandlerMiddleware(checkSession),
+		server.WithResourceHandlerMiddleware(checkResourceScope),
+		server.WithPromptHandlerMiddleware(checkPromptScope),
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"xiaoyuzhoufm-mcp/internal/auth"
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestIsWriteTool(t *testing.T) {
+	tests := []struct {
+		name  string
+		tool  mcp.Tool
+		write bool
+	}{
+		{"read tool", mcp.NewTool("read", readTool()), false},
+		{"write tool", mcp.NewTool("write", writeTool()), true},
+		{"unclassified tool", mcp.NewTool("unclassified"), true},
+		{"no annotations at all", mcp.Tool{Name: "bare"}, true},
+		{"explicitly not read-only", mcp.NewTool("hinted", mcp.WithReadOnlyHintAnnotation(false)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isWriteTool(tt.tool); got != tt.write {
+				t.Errorf("isWriteTool = %v, want %v", got, tt.write)
+			}
+		})
+	}
+}
+
+func TestFilterToolsByScope(t *testing.T) {
+	tools := []mcp.Tool{
+		mcp.NewTool("search", readTool()),
+		mcp.NewTool("follow", writeTool()),
+		mcp.NewTool("unclassified"),
+	}
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      []string
+	}{
+		{"unauthenticated", nil, []string{"search", "follow", "unclassified"}},
+		{"read scope", &auth.Principal{Name: "reader", Scopes: []string{auth.ScopeRead}}, []string{"search"}},
+		{"write scope only", &auth.Principal{Name: "writer", Scopes: []string{auth.ScopeWrite}}, []string{"follow", "unclassified"}},
+		{"both scopes", &auth.Principal{Name: "owner", Scopes: []string{auth.ScopeRead, auth.ScopeWrite}}, []string{"search", "follow", "unclassified"}},
+		{"no scopes", &auth.Principal{Name: "nobody"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+			var got []string
+			for _, tool := range filterToolsByScope(ctx, tools) {
+				got = append(got, tool.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("filterToolsByScope = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRegisteredToolsAreClassified checks every registered tool against the scope it needs,
+// so that a new tool can't become callable with xyz:read by accident.
+func TestRegisteredToolsAreClassified(t *testing.T) {
+	writeTools := []string{
+		"set_episode_favorite", "follow_user", "unfollow_user", "clap_episode", "pick_episode", "unpick_episode",
+		"start_login", "complete_login", "logout",
+	}
+	s := NewMCPServer()
+	registerTenantTools(s, xyzclient.NewTenantStore(t.TempDir()))
+
+	registered := s.ListTools()
+	for _, name := range writeTools {
+		if registered[name] == nil {
+			t.Errorf("write tool %s is not registered", name)
+		}
+	}
+	for name, serverTool := range registered {
+		tool := serverTool.Tool
+		wantWrite := slices.Contains(writeTools, name)
+		if got := isWriteTool(tool); got != wantWrite {
+			t.Errorf("isWriteTool(%s) = %v, want %v", name, got, wantWrite)
+		}
+		if tool.Annotations.ReadOnlyHint == nil {
+			t.Errorf("%s is neither a readTool nor a writeTool", name)
+		}
+		_, hasConfirm := tool.InputSchema.Properties["confirm"]
+		if wantWrite && name != "start_login" && !hasConfirm {
+			t.Errorf("write tool %s has no 'confirm' argument", name)
+		}
+	}
+}
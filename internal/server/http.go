@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"xiaoyuzhoufm-mcp/internal/auth"
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Supported network transports for the serve command.
+const (
+	TransportHTTP = "http" // Streamable HTTP
+	TransportSSE  = "sse"  // Legacy HTTP+SSE
+)
+
+// shutdownTimeout bounds how long in-flight requests get to finish after a stop signal.
+const shutdownTimeout = 10 * time.Second
+
+// defaultAllowedOrigins are accepted when no allowlist is configured, so that only
+// local browser contexts can reach the server.
+var defaultAllowedOrigins = []string{"localhost", "127.0.0.1", "[::1]"}
+
+// HTTPOptions configures RunHTTPServer.
+type HTTPOptions struct {
+	Transport string // TransportHTTP or TransportSSE
+	Addr      string // Listen address, e.g. ":8080"
+	BasePath  string // Path the MCP endpoint is mounted at, e.g. "/mcp"
+	// AllowedOrigins lists the Origin values accepted from browsers, either as a full origin
+	// ("https://app.example.com") or a bare host matching any scheme and port ("localhost").
+	// Requests without an Origin header are always accepted, since they do not come from a browser.
+	AllowedOrigins []string
+	// Auth enables bearer-token authentication with the given API keys and OAuth clients.
+	// A nil Auth serves every request unauthenticated with full access.
+	Auth *auth.Config
+	// Tenants enables multi-tenant mode: each authenticated principal acts as its own Xiaoyuzhou
+	// account, stored in this TenantStore. Requires Auth. A nil Tenants serves the process-wide account.
+	Tenants *xyzclient.TenantStore
+	// PublicURL is the externally visible base URL, used as the OAuth issuer and in the protected
+	// resource metadata. Defaults to http://localhost plus the port of Addr.
+	PublicURL string
+	// PromptsDir holds prompt templates that override or extend the built-in prompts.
+	PromptsDir string
+}
+
+// shutdowner is implemented by both mcp-go network transports.
+type shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// RunHTTPServer serves the MCP server over streamable HTTP or SSE until SIGINT or SIGTERM,
+// then shuts down gracefully.
+func RunHTTPServer(opts HTTPOptions) error {
+	basePath := "/" + strings.Trim(opts.BasePath, "/")
+	allowedOrigins := opts.AllowedOrigins
+	if len(allowedOrigins) == 0 {
+		allowedOrigins = defaultAllowedOrigins
+	}
+	publicURL := strings.TrimSuffix(opts.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = defaultPublicURL(opts.Addr)
+	}
+
+	if opts.Tenants != nil && opts.Auth == nil {
+		return fmt.Errorf("multi-tenant mode requires authentication")
+	}
+
+	mux := http.NewServeMux()
+	protect := func(h http.Handler) http.Handler { return h }
+	var serverOptions []server.ServerOption
+	if opts.Auth != nil {
+		as := auth.NewAuthorizationServer(publicURL, opts.Auth)
+		as.Register(mux)
+
+		resourceMetadata := server.ProtectedResourceMetadataConfig{
+			Resource:               publicURL + basePath,
+			AuthorizationServers:   []string{as.Issuer()},
+			ScopesSupported:        auth.SupportedScopes,
+			BearerMethodsSupported: []string{"header"},
+			ResourceName:           "XiaoyuzhouFM MCP Server",
+		}
+		resourceMetadataPath := server.ProtectedResourceMetadataPath(resourceMetadata.Resource)
+		mux.Handle(resourceMetadataPath, server.NewProtectedResourceMetadataHandler(resourceMetadata))
+
+		protect = func(h http.Handler) http.Handler {
+			return withAuthentication(h, as, publicURL+resourceMetadataPath)
+		}
+		serverOptions = authorizationOptions()
+	} else {
+		slog.Warn("Authentication is disabled: anyone who can reach this server acts as the logged-in Xiaoyuzhou account.")
+	}
+
+	if opts.Tenants != nil {
+		serverOptions = append(serverOptions, tenantOptions(opts.Tenants)...)
+	}
+
+	s := NewMCPServer(serverOptions...)
+	if err := registerPrompts(s, opts.PromptsDir); err != nil {
+		slog.Warn("Prompt templates are disabled.", "error", err)
+	}
+	accountContext := processAccountContext
+	if opts.Tenants != nil {
+		registerTenantTools(s, opts.Tenants)
+		accountContext = tenantAccountContext(opts.Tenants)
+	}
+	httpServer := &http.Server{Addr: opts.Addr, Handler: withOriginValidation(mux, allowedOrigins)}
+
+	var transport shutdowner
+	switch opts.Transport {
+	case TransportHTTP:
+		streamable := server.NewStreamableHTTPServer(s,
+			server.WithEndpointPath(basePath),
+			server.WithStreamableHTTPServer(httpServer),
+		)
+		mux.Handle(basePath, protect(streamable))
+		transport = streamable
+	case TransportSSE:
+		sse := server.NewSSEServer(s,
+			server.WithStaticBasePath(basePath),
+			server.WithHTTPServer(httpServer),
+		)
+		// The SSE transport serves <basePath>/sse and <basePath>/message.
+		mux.Handle(strings.TrimSuffix(basePath, "/")+"/", protect(sse))
+		transport = sse
+	default:
+		return fmt.Errorf("unsupported transport %q, expected %q or %q", opts.Transport, TransportHTTP, TransportSSE)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := enableSubscriptions(ctx, s, accountContext); err != nil {
+		slog.Warn("Resource subscriptions are disabled.", "error", err)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("MCP HTTP Server listening", "transport", opts.Transport, "addr", opts.Addr, "basePath", basePath)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("MCP HTTP Server failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down MCP HTTP Server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := transport.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("MCP HTTP Server shutdown failed: %w", err)
+	}
+	slog.Debug("MCP HTTP Server stopped.")
+	return nil
+}
+
+// defaultPublicURL derives a local base URL from the listen address.
+func defaultPublicURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://localhost"
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// withOriginValidation rejects browser requests whose Origin header is not allowlisted,
+// which protects a locally reachable server from being driven by arbitrary web pages.
+func withOriginValidation(next http.Handler, allowedOrigins []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && !isAllowedOrigin(origin, allowedOrigins) {
+			slog.Warn("Rejected request from disallowed origin.", "origin", origin, "remoteAddr", r.RemoteAddr)
+			http.Error(w, "Forbidden: origin not allowed", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isAllowedOrigin matches an Origin header against full origins or bare hosts in the allowlist.
+func isAllowedOrigin(origin string, allowedOrigins []string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	hostname := parsed.Hostname()
+	if strings.Contains(hostname, ":") {
+		hostname = "[" + hostname + "]"
+	}
+	for _, allowed := range allowedOrigins {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if allowed == "*" || strings.EqualFold(allowed, origin) || strings.EqualFold(allowed, hostname) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"xiaoyuzhoufm-mcp/internal/tools"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerPrompts adds the built-in prompt templates, overridden or extended by the templates
+// in dir.
+func registerPrompts(s *server.MCPServer, dir string) error {
+	prompts, err := tools.LoadPromptTemplates(dir)
+	if err != nil {
+		return err
+	}
+	for _, pt := range prompts {
+		s.AddPrompt(pt.Prompt, pt.Handler)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"xiaoyuzhoufm-mcp/internal/tools"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerResourceTemplates exposes podcasts, episodes and users as MCP resources, so that
+// clients can attach them to a conversation as context instead of calling a tool.
+func registerResourceTemplates(s *server.MCPServer) {
+	podcastTemplate := mcp.NewResourceTemplate(tools.PodcastResourceTemplate, "podcast",
+		mcp.WithTemplateTitle("播客详情"),
+		mcp.WithTemplateDescription("播客的详细信息，包括简介、主播、订阅数和标签。"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(podcastTemplate, tools.ReadPodcastResource)
+
+	podcastEpisodesTemplate := mcp.NewResourceTemplate(tools.PodcastEpisodesResourceTemplate, "podcast_episodes",
+		mcp.WithTemplateTitle("播客最新单集"),
+		mcp.WithTemplateDescription("播客最新的 20 期单集的精简列表。"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(podcastEpisodesTemplate, tools.ReadPodcastEpisodesResource)
+
+	episodeTemplate := mcp.NewResourceTemplate(tools.EpisodeResourceTemplate, "episode",
+		mcp.WithTemplateTitle("单集详情"),
+		mcp.WithTemplateDescription("单集的详细信息，包括所属播客、时长、发布时间和媒体地址。"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(episodeTemplate, tools.ReadEpisodeResource)
+
+	episodeShownotesTemplate := mcp.NewResourceTemplate(tools.EpisodeShownotesResourceTemplate, "episode_shownotes",
+		mcp.WithTemplateTitle("单集节目笔记"),
+		mcp.WithTemplateDescription("单集的节目笔记（Shownotes），已转换为 Markdown。"),
+		mcp.WithTemplateMIMEType("text/markdown"),
+	)
+	s.AddResourceTemplate(episodeShownotesTemplate, tools.ReadEpisodeShownotesResource)
+
+	userTemplate := mcp.NewResourceTemplate(tools.UserResourceTemplate, "user",
+		mcp.WithTemplateTitle("用户资料"),
+		mcp.WithTemplateDescription("用户的公开资料；uid 可为 me 表示当前登录用户。"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(userTemplate, tools.ReadUserResource)
+}
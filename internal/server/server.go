@@ -1,37 +1,83 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
 	"log/slog"
+	"reflect"
 
 	"xiaoyuzhoufm-mcp/internal/tools"
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// RunStdioServer initializes and runs a basic MCP server over stdio.
-func RunStdioServer() {
+// RunStdioServer initializes and runs a basic MCP server over stdio. Prompt templates are
+// loaded from promptsDir in addition to the built-in ones.
+func RunStdioServer(promptsDir string) {
+	s := NewMCPServer()
+	if err := registerPrompts(s, promptsDir); err != nil {
+		slog.Warn("Prompt templates are disabled.", "error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := enableSubscriptions(ctx, s, processAccountContext); err != nil {
+		slog.Warn("Resource subscriptions are disabled.", "error", err)
+	}
+
+	slog.Debug("MCP Stdio Server starting with all tools registered...")
+
+	if err := server.ServeStdio(s); err != nil {
+		slog.Error("MCP Stdio Server failed", "error", err)
+	}
+
+	slog.Debug("MCP Stdio Server stopped.")
+}
+
+// NewMCPServer creates the MCP server with all tools registered. It is shared by all transports;
+// extra options let a transport add hooks, filters or middleware.
+func NewMCPServer(extraOptions ...server.ServerOption) *server.MCPServer {
+	options := []server.ServerOption{
+		server.WithLogging(), // Optional: enable basic logging
+		server.WithOutputSchemaValidation(),
+	}
 	s := server.NewMCPServer(
-		"XiaoyuzhouFM Stdio Server", // Server name
-		"0.0.1",                     // Server version
-		server.WithLogging(),        // Optional: enable basic logging
+		"XiaoyuzhouFM MCP Server", // Server name
+		"0.0.1",                   // Server version
+		append(options, extraOptions...)...,
+	)
+
+	whoamiTool := mcp.NewTool("whoami",
+		mcp.WithDescription("获取当前登录用户的资料、统计数据以及 Token 状态（上次刷新时间、预计过期时间）。"),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.WhoamiResult](),
 	)
+	s.AddTool(whoamiTool, tools.WhoamiHandler)
 
 	getUserProfileByIDTool := mcp.NewTool("get_user_profile_by_id",
 		mcp.WithDescription("根据用户 UID 获取指定用户的公开信息。"),
 		mcp.WithString("user_id",
-			mcp.Description("要查询的用户的唯一标识符 (UID)。"),
+			mcp.Description("要查询的用户的唯一标识符 (UID)，传入 'me' 表示当前登录用户。"),
 			mcp.Required(),
 		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[xyzclient.UserProfileData](),
 	)
 	s.AddTool(getUserProfileByIDTool, tools.GetUserProfileByIDHandler)
 
 	getUserStatsTool := mcp.NewTool("get_user_stats",
 		mcp.WithDescription("获取指定用户的统计数据（如关注数、粉丝数、订阅播客数、收听时长）。"),
 		mcp.WithString("user_id",
-			mcp.Description("要查询的用户的唯一标识符 (UID)。"),
+			mcp.Description("要查询的用户的唯一标识符 (UID)，传入 'me' 表示当前登录用户。"),
 			mcp.Required(),
 		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[xyzclient.UserStatsData](),
 	)
 	s.AddTool(getUserStatsTool, tools.GetUserStatsHandler)
 
@@ -41,6 +87,9 @@ func RunStdioServer() {
 			mcp.Description("播客的唯一标识符 (PID)。"),
 			mcp.Required(),
 		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[xyzclient.PodcastDetailData](),
 	)
 	s.AddTool(podcastDetailsTool, tools.GetPodcastDetailsHandler)
 
@@ -54,36 +103,71 @@ func RunStdioServer() {
 			mcp.Description("排序方式。"),
 			mcp.Enum("asc", "desc"),
 		),
-		mcp.WithObject("load_more_key",
-			mcp.Description("用于分页查询的键。对应 API 中的 loadMoreKey 对象。"), // Description for the object itself
-			// The load_more_key object itself is optional.
-			mcp.Properties(map[string]interface{}{ // Corrected: Pass a map to mcp.Properties
-				"direction": map[string]interface{}{
-					"type":        "string",
-					"description": "分页方向 (例如 'NEXT')。",
-				},
-				"pubDate": map[string]interface{}{
-					"type":        "string",
-					"description": "分页锚点的发布日期 (ISO 8601 格式的字符串)。",
-				},
-				"id": map[string]interface{}{
-					"type":        "string",
-					"description": "分页锚点的单集ID。",
-				},
-			}),
+		mcp.WithString("access",
+			mcp.Description("按付费状态过滤当前页：all（默认）、free（免费）、paid（付费）、purchased（已购买）。未购买的付费单集会标记为 LOCKED。"),
+			mcp.Enum("all", "free", "paid", "purchased"),
 		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.EpisodeListResult](),
 	)
 	s.AddTool(listPodcastEpisodesTool, tools.ListPodcastEpisodesHandler)
 
+	listPopularEpisodesTool := mcp.NewTool("list_popular_episodes",
+		mcp.WithDescription("获取指定播客由平台统计的热门单集，适合回答“这个节目从哪一期开始听”。"),
+		mcp.WithString("podcast_id",
+			mcp.Description("播客的唯一标识符 (PID)。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("返回的数量，默认 10，最大 50。"),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.PopularEpisodesResult](),
+	)
+	s.AddTool(listPopularEpisodesTool, tools.ListPopularEpisodesHandler)
+
+	findSimilarPodcastsTool := mcp.NewTool("find_similar_podcasts",
+		mcp.WithDescription("查找与指定播客相似的节目。优先使用平台的相关推荐；若平台无结果，则根据共同主播、相同标签和简介关键词在本地计算相似度，并给出理由。"),
+		mcp.WithString("podcast_id",
+			mcp.Description("播客的唯一标识符 (PID)。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("返回的数量，默认 10，最大 30。"),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.SimilarPodcastsResult](),
+	)
+	s.AddTool(findSimilarPodcastsTool, tools.FindSimilarPodcastsHandler)
+
 	getEpisodeDetailsTool := mcp.NewTool("get_episode_details",
 		mcp.WithDescription("获取指定单集的详细信息。"),
 		mcp.WithString("episode_id",
 			mcp.Description("要查询的单集的唯一标识符 (EID)。"),
 			mcp.Required(),
 		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[xyzclient.Episode](),
 	)
 	s.AddTool(getEpisodeDetailsTool, tools.GetEpisodeDetailsHandler)
 
+	getEpisodeMediaURLTool := mcp.NewTool("get_episode_media_url",
+		mcp.WithDescription("获取单集当前可播放的最佳媒体地址、MIME 类型和文件大小。私有或付费音频会返回有时效的签名地址。"),
+		mcp.WithString("episode_id",
+			mcp.Description("单集的唯一标识符 (EID)。"),
+			mcp.Required(),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.EpisodeMediaURL](),
+	)
+	s.AddTool(getEpisodeMediaURLTool, tools.GetEpisodeMediaURLHandler)
+
 	// Search Podcasts Tool
 	searchPodcastsTool := mcp.NewTool("search_podcasts",
 		mcp.WithDescription("根据关键词搜索播客。"),
@@ -91,21 +175,10 @@ func RunStdioServer() {
 			mcp.Description("搜索关键词。"),
 			mcp.Required(),
 		),
-		mcp.WithObject("load_more_key",
-			mcp.Description("用于分页查询的键，存在于先前搜索请求的响应中。"),
-			// load_more_key object itself is optional
-			mcp.Properties(map[string]interface{}{
-				// API example shows loadMoreKey as an integer for podcast/episode/user search results
-				"loadMoreKey": map[string]interface{}{
-					"type":        "integer",
-					"description": "分页加载键（通常是数字）。",
-				},
-				"searchId": map[string]interface{}{
-					"type":        "string",
-					"description": "搜索会话ID。",
-				},
-			}),
-		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.SearchResult[xyzclient.PodcastSearchResultItem]](),
 	)
 	s.AddTool(searchPodcastsTool, tools.SearchPodcastsHandler)
 
@@ -120,20 +193,10 @@ func RunStdioServer() {
 			mcp.Description("可选参数，如果需要在特定播客内搜索单集，请提供播客ID。"),
 			// This parameter is optional, so no mcp.Required()
 		),
-		mcp.WithObject("load_more_key",
-			mcp.Description("用于分页查询的键，存在于先前搜索请求的响应中。"),
-			mcp.Properties(map[string]interface{}{
-				// API example shows loadMoreKey as an integer for podcast/episode/user search results
-				"loadMoreKey": map[string]interface{}{
-					"type":        "integer",
-					"description": "分页加载键（通常是数字）。",
-				},
-				"searchId": map[string]interface{}{
-					"type":        "string",
-					"description": "搜索会话ID。",
-				},
-			}),
-		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.SearchResult[xyzclient.EpisodeSearchResultItem]](),
 	)
 	s.AddTool(searchEpisodesTool, tools.SearchEpisodesHandler)
 
@@ -144,28 +207,472 @@ func RunStdioServer() {
 			mcp.Description("搜索关键词。"),
 			mcp.Required(),
 		),
-		mcp.WithObject("load_more_key",
-			mcp.Description("用于分页查询的键，存在于先前搜索请求的响应中。"),
-			mcp.Properties(map[string]interface{}{
-				// API example shows loadMoreKey as an integer for podcast/episode/user search results
-				"loadMoreKey": map[string]interface{}{
-					"type":        "integer",
-					"description": "分页加载键（通常是数字）。",
-				},
-				"searchId": map[string]interface{}{
-					"type":        "string",
-					"description": "搜索会话ID。",
-				},
-			}),
-		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.SearchResult[xyzclient.UserSearchResultItem]](),
 	)
 	s.AddTool(searchUsersTool, tools.SearchUsersHandler)
 
-	slog.Debug("MCP Stdio Server starting with 'hello', 'get_user_profile_by_id', 'get_user_stats', 'get_podcast_details', 'list_podcast_episodes', 'get_episode_details', 'search_podcasts', 'search_episodes', and 'search_users' tools...")
+	suggestSearchTermsTool := mcp.NewTool("suggest_search_terms",
+		mcp.WithDescription("根据不完整的关键词获取搜索联想建议，用于在正式搜索前细化模糊的需求。"),
+		mcp.WithString("keyword",
+			mcp.Description("部分关键词。"),
+			mcp.Required(),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[[]xyzclient.SearchSuggestion](),
+	)
+	s.AddTool(suggestSearchTermsTool, tools.SuggestSearchTermsHandler)
 
-	if err := server.ServeStdio(s); err != nil {
-		slog.Error("MCP Stdio Server failed", "error", err)
+	getHotSearchesTool := mcp.NewTool("get_hot_searches",
+		mcp.WithDescription("获取小宇宙平台当前的热门搜索词以及搜索框预设推荐词。"),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.HotSearchesResult](),
+	)
+	s.AddTool(getHotSearchesTool, tools.GetHotSearchesHandler)
+
+	// Listening History Tools
+	listListeningHistoryTool := mcp.NewTool("list_listening_history",
+		mcp.WithDescription("获取当前登录用户最近收听的单集列表（按收听时间倒序）。"),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		mcp.WithString("since",
+			mcp.Description("可选参数，只返回该时间之后收听的单集。格式为 YYYY-MM-DD 或 RFC 3339。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.HistoryListResult](),
+	)
+	s.AddTool(listListeningHistoryTool, tools.ListListeningHistoryHandler)
+
+	getPlaybackProgressTool := mcp.NewTool("get_playback_progress",
+		mcp.WithDescription("获取当前登录用户在指定单集上的播放进度（播放位置、时长、完成百分比、是否听完）。"),
+		mcp.WithArray("episode_ids",
+			mcp.Description("要查询的单集 EID 列表，最多 20 个。"),
+			mcp.Required(),
+			stringItems(),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[[]tools.EpisodeProgress](),
+	)
+	s.AddTool(getPlaybackProgressTool, tools.GetPlaybackProgressHandler)
+
+	listInProgressEpisodesTool := mcp.NewTool("list_in_progress_episodes",
+		mcp.WithDescription("获取当前登录用户已开始收听但尚未听完的单集及其播放进度，用于“继续收听”。"),
+		mcp.WithNumber("limit",
+			mcp.Description("返回的数量，默认 10，最大 50。"),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[[]tools.EpisodeProgress](),
+	)
+	s.AddTool(listInProgressEpisodesTool, tools.ListInProgressEpisodesHandler)
+
+	// Favorite Tools
+	listFavoritesTool := mcp.NewTool("list_favorites",
+		mcp.WithDescription("获取当前登录用户收藏的单集列表。"),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.FavoriteListResult](),
+	)
+	s.AddTool(listFavoritesTool, tools.ListFavoritesHandler)
+
+	setEpisodeFavoriteTool := mcp.NewTool("set_episode_favorite",
+		mcp.WithDescription("收藏或取消收藏指定单集。这是写操作，必须先征得用户同意。"),
+		mcp.WithString("episode_id",
+			mcp.Description("单集的唯一标识符 (EID)。"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("favorited",
+			mcp.Description("true 表示收藏，false 表示取消收藏。"),
+			mcp.Required(),
+		),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(setEpisodeFavoriteTool, tools.SetEpisodeFavoriteHandler)
+
+	// Inbox Tool
+	getInboxTool := mcp.NewTool("get_inbox",
+		mcp.WithDescription("获取当前登录用户的收件箱：所有已订阅播客的最新单集，按发布时间倒序。"),
+		mcp.WithNumber("limit",
+			mcp.Description("期望返回的数量，默认 20，最大 50。"),
+		),
+		mcp.WithString("since",
+			mcp.Description("可选参数，只返回该时间之后发布的单集。格式为 YYYY-MM-DD 或 RFC 3339。"),
+		),
+		mcp.WithBoolean("unplayed_only",
+			mcp.Description("可选参数，为 true 时只返回未播放过的单集。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.InboxResult](),
+	)
+	s.AddTool(getInboxTool, tools.GetInboxHandler)
+
+	// Top List Tool
+	getTopListTool := mcp.NewTool("get_top_list",
+		mcp.WithDescription("获取小宇宙平台的榜单（热门单集、飙升播客、新星播客或分类榜），返回排名、播客/单集摘要以及榜单更新日期。"),
+		mcp.WithString("list_type",
+			mcp.Description("榜单类型：hot_episodes（24 小时热门单集）、rising_podcasts（飙升播客）、new_podcasts（新星播客）、category（分类榜）。"),
+			mcp.Enum("hot_episodes", "rising_podcasts", "new_podcasts", "category"),
+			mcp.Required(),
+		),
+		mcp.WithString("category_id",
+			mcp.Description("分类 ID，仅当 list_type 为 category 时必填。"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("返回的条目数量，默认 20，最大 100。"),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.TopListResult](),
+	)
+	s.AddTool(getTopListTool, tools.GetTopListHandler)
+
+	// Category and Discovery Tools
+	listCategoriesTool := mcp.NewTool("list_categories",
+		mcp.WithDescription("获取小宇宙的播客分类树（包含分类 ID 和名称），可配合 browse_category 和 get_top_list 使用。"),
+		readTool(),
+		withOutputOptions(),
+		mcp.WithRawOutputSchema(json.RawMessage(categoryTreeSchema)),
+	)
+	s.AddTool(listCategoriesTool, tools.ListCategoriesHandler)
+
+	browseCategoryTool := mcp.NewTool("browse_category",
+		mcp.WithDescription("浏览指定分类下的播客列表，用于在没有关键词时推荐节目。"),
+		mcp.WithString("category_id",
+			mcp.Description("分类 ID，可通过 list_categories 获取。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.CategoryPodcastsResult](),
+	)
+	s.AddTool(browseCategoryTool, tools.BrowseCategoryHandler)
+
+	getDiscoveryFeedTool := mcp.NewTool("get_discovery_feed",
+		mcp.WithDescription("获取当前登录用户的个性化发现页推荐（单集和播客）。"),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.DiscoveryFeedResult](),
+	)
+	s.AddTool(getDiscoveryFeedTool, tools.GetDiscoveryFeedHandler)
+
+	// User Relation Tools
+	listFollowersTool := mcp.NewTool("list_followers",
+		mcp.WithDescription("获取指定用户的粉丝列表。"),
+		mcp.WithString("user_id",
+			mcp.Description("要查询的用户的唯一标识符 (UID)，传入 'me' 表示当前登录用户。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.RelationListResult](),
+	)
+	s.AddTool(listFollowersTool, tools.ListFollowersHandler)
+
+	listFollowingTool := mcp.NewTool("list_following",
+		mcp.WithDescription("获取指定用户关注的用户列表。"),
+		mcp.WithString("user_id",
+			mcp.Description("要查询的用户的唯一标识符 (UID)，传入 'me' 表示当前登录用户。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.RelationListResult](),
+	)
+	s.AddTool(listFollowingTool, tools.ListFollowingHandler)
+
+	followUserTool := mcp.NewTool("follow_user",
+		mcp.WithDescription("以当前登录用户身份关注指定用户。这是写操作，必须先征得用户同意。"),
+		mcp.WithString("user_id",
+			mcp.Description("要关注的用户的唯一标识符 (UID)。"),
+			mcp.Required(),
+		),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(followUserTool, tools.FollowUserHandler)
+
+	unfollowUserTool := mcp.NewTool("unfollow_user",
+		mcp.WithDescription("以当前登录用户身份取消关注指定用户。这是写操作，必须先征得用户同意。"),
+		mcp.WithString("user_id",
+			mcp.Description("要取消关注的用户的唯一标识符 (UID)。"),
+			mcp.Required(),
+		),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(unfollowUserTool, tools.UnfollowUserHandler)
+
+	// Clap and Pick Tools
+	clapEpisodeTool := mcp.NewTool("clap_episode",
+		mcp.WithDescription("以当前登录用户身份为单集点赞（鼓掌）。这是写操作，必须先征得用户同意。"),
+		mcp.WithString("episode_id",
+			mcp.Description("单集的唯一标识符 (EID)。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("点赞次数，默认 1，最大 10。"),
+		),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(clapEpisodeTool, tools.ClapEpisodeHandler)
+
+	pickEpisodeTool := mcp.NewTool("pick_episode",
+		mcp.WithDescription("以当前登录用户身份精选单集并附上推荐语，精选会公开展示在用户主页上。这是写操作，必须先征得用户同意。"),
+		mcp.WithString("episode_id",
+			mcp.Description("单集的唯一标识符 (EID)。"),
+			mcp.Required(),
+		),
+		mcp.WithString("text",
+			mcp.Description("推荐语，不超过 140 字。"),
+			mcp.Required(),
+		),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(pickEpisodeTool, tools.PickEpisodeHandler)
+
+	unpickEpisodeTool := mcp.NewTool("unpick_episode",
+		mcp.WithDescription("取消当前登录用户对单集的精选。这是写操作，必须先征得用户同意。"),
+		mcp.WithString("episode_id",
+			mcp.Description("单集的唯一标识符 (EID)。"),
+			mcp.Required(),
+		),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(unpickEpisodeTool, tools.UnpickEpisodeHandler)
+
+	listUserPicksTool := mcp.NewTool("list_user_picks",
+		mcp.WithDescription("获取指定用户公开的精选单集及推荐语。"),
+		mcp.WithString("user_id",
+			mcp.Description("要查询的用户的唯一标识符 (UID)，传入 'me' 表示当前登录用户。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.PickListResult](),
+	)
+	s.AddTool(listUserPicksTool, tools.ListUserPicksHandler)
+
+	// Topic Tools
+	getTopicTool := mcp.NewTool("get_topic",
+		mcp.WithDescription("获取圈子（话题社区）的详情。可以传入圈子 ID（单集的 topicId），或传入 hasTopic 为 true 的播客 PID。"),
+		mcp.WithString("topic_id",
+			mcp.Description("圈子 ID，与 podcast_id 二选一。"),
+		),
+		mcp.WithString("podcast_id",
+			mcp.Description("播客的唯一标识符 (PID)，与 topic_id 二选一。"),
+		),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[xyzclient.Topic](),
+	)
+	s.AddTool(getTopicTool, tools.GetTopicHandler)
+
+	listTopicPostsTool := mcp.NewTool("list_topic_posts",
+		mcp.WithDescription("获取圈子中的帖子（听众讨论），按时间倒序。"),
+		mcp.WithString("topic_id",
+			mcp.Description("圈子 ID，可通过 get_topic 获取。"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.TopicPostListResult](),
+	)
+	s.AddTool(listTopicPostsTool, tools.ListTopicPostsHandler)
+
+	// Paid Content Tool
+	listPurchasedContentTool := mcp.NewTool("list_purchased_content",
+		mcp.WithDescription("获取当前登录用户已购买的付费播客和单集。"),
+		mcp.WithNumber("limit",
+			mcp.Description("每页返回的数量，默认 20，最大 50。"),
+		),
+		withCursor(),
+		readTool(),
+		withOutputOptions(),
+		withOutputSchema[tools.PurchasedListResult](),
+	)
+	s.AddTool(listPurchasedContentTool, tools.ListPurchasedContentHandler)
+
+	registerResourceTemplates(s)
+
+	return s
+}
+
+// stringItems declares that an array property holds strings.
+func stringItems() mcp.PropertyOption {
+	return func(schema map[string]interface{}) {
+		schema["items"] = map[string]interface{}{"type": "string"}
 	}
+}
 
-	slog.Debug("MCP Stdio Server stopped.")
+// withOutputOptions adds the 'detail', 'fields' and 'format' arguments that shape a tool's result.
+func withOutputOptions() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("detail",
+			mcp.Description("返回内容的详细程度：minimal 仅保留标识和挑选所需的关键字段；standard（默认）去掉图片、权限、分享设置等冗余字段；full 返回完整的原始数据。"),
+			mcp.Enum(tools.DetailLevels...),
+		)(tool)
+		mcp.WithArray("fields",
+			mcp.Description("只返回指定的字段，指定后忽略 detail。路径以 '.' 分隔，数组会自动展开，'*' 匹配任意字段，例如 [\"data.title\", \"data.podcast.title\"]。"),
+			stringItems(),
+		)(tool)
+		mcp.WithString("format",
+			mcp.Description("返回格式：json（默认）为紧凑的 JSON；markdown 将播客渲染为卡片、单集列表渲染为表格，并加粗搜索关键词，适合直接展示给用户；text 为纯文本。"),
+			mcp.Enum(tools.OutputFormats...),
+		)(tool)
+	}
+}
+
+// dataResult is the structured content of tools whose result is an array, which is returned
+// wrapped in an object.
+type dataResult[T any] struct {
+	Data T `json:"data"`
+}
+
+// withOutputSchema declares the schema of a tool's structured content, generated from the
+// result type T. The 'detail' and 'fields' arguments may drop any key, so no property is
+// required and objects stay open.
+func withOutputSchema[T any]() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		if reflect.TypeFor[T]().Kind() == reflect.Slice {
+			mcp.WithOutputSchema[dataResult[T]]()(tool)
+		} else {
+			mcp.WithOutputSchema[T]()(tool)
+		}
+		tool.OutputSchema.Required = nil
+		tool.OutputSchema.AdditionalProperties = nil
+		relaxSchema(tool.OutputSchema.Properties)
+		relaxSchema(tool.OutputSchema.Defs)
+	}
+}
+
+// categoryTreeSchema describes the result of list_categories. The schema generator can't
+// express the recursive category tree, so it is written by hand.
+const categoryTreeSchema = `{
+	"type": "object",
+	"properties": {"data": {"type": "array", "items": {"$ref": "#/$defs/category"}}},
+	"$defs": {
+		"category": {
+			"type": "object",
+			"properties": {
+				"id": {"type": "string"},
+				"name": {"type": "string"},
+				"children": {"type": "array", "items": {"$ref": "#/$defs/category"}}
+			}
+		}
+	}
+}`
+
+// relaxSchema removes 'required' lists and closed 'additionalProperties' from a schema tree.
+// Go maps are open objects that marshal as null when nil, so those are made nullable.
+func relaxSchema(node interface{}) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if _, ok := v["required"].([]interface{}); ok {
+			delete(v, "required")
+		}
+		if open, ok := v["additionalProperties"]; ok && v["type"] == "object" && open != false {
+			v["type"] = []interface{}{"null", "object"}
+		}
+		if closed, ok := v["additionalProperties"].(bool); ok && !closed {
+			delete(v, "additionalProperties")
+		}
+		for _, child := range v {
+			relaxSchema(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			relaxSchema(child)
+		}
+	}
+}
+
+// withCursor adds the 'cursor' argument of paged tools.
+func withCursor() mcp.ToolOption {
+	return mcp.WithString("cursor",
+		mcp.Description("分页游标。获取下一页时原样传入上一页结果中的 next_cursor，并保持其他参数不变；不传则从第一页开始。"),
+	)
+}
+
+// readTool marks a tool that only reads data, which principals with the xyz:read scope may
+// call. Tools not marked this way count as write tools (see isWriteTool).
+func readTool() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithReadOnlyHintAnnotation(true)(tool)
+		mcp.WithDestructiveHintAnnotation(false)(tool)
+	}
+}
+
+// writeTool marks a tool that changes account data or the login state, which requires the
+// xyz:write scope. The changes are reversible, so the tool is not destructive.
+func writeTool() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithReadOnlyHintAnnotation(false)(tool)
+		mcp.WithDestructiveHintAnnotation(false)(tool)
+	}
+}
+
+// withConfirm adds the 'confirm' argument that write tools require before changing account data.
+func withConfirm() mcp.ToolOption {
+	return mcp.WithBoolean("confirm",
+		mcp.Description("必须在用户明确同意后设置为 true，否则不会执行任何修改。"),
+		mcp.Required(),
+	)
+}
+
+// withHooks adds hooks to the server's single Hooks instance, creating it if needed.
+// server.WithHooks replaces the instance, so every option that adds hooks goes through this.
+func withHooks(add func(hooks *server.Hooks)) server.ServerOption {
+	return func(s *server.MCPServer) {
+		hooks := s.GetHooks()
+		if hooks == nil {
+			hooks = &server.Hooks{}
+			server.WithHooks(hooks)(s)
+		}
+		add(hooks)
+	}
 }
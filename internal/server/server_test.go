@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestRelaxSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"drops required lists",
+			`{"type":"object","required":["a"],"properties":{"a":{"type":"string"}}}`,
+			`{"type":"object","properties":{"a":{"type":"string"}}}`},
+		{"keeps a property named required",
+			`{"properties":{"required":{"type":"boolean"}}}`,
+			`{"properties":{"required":{"type":"boolean"}}}`},
+		{"opens closed objects",
+			`{"type":"object","additionalProperties":false,"properties":{}}`,
+			`{"type":"object","properties":{}}`},
+		{"makes maps nullable",
+			`{"type":"object","additionalProperties":{"type":"string"}}`,
+			`{"type":["null","object"],"additionalProperties":{"type":"string"}}`},
+		{"recurses into nested schemas and arrays",
+			`{"items":{"type":"object","required":["b"],"additionalProperties":false},"anyOf":[{"required":["c"]}]}`,
+			`{"items":{"type":"object"},"anyOf":[{}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema, want interface{}
+			if err := json.Unmarshal([]byte(tt.schema), &schema); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			relaxSchema(schema)
+			if !reflect.DeepEqual(schema, want) {
+				got, _ := json.Marshal(schema)
+				t.Errorf("relaxSchema = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+type schemaItem struct {
+	ID   string            `json:"id"`
+	Tags map[string]string `json:"tags"`
+}
+
+func TestWithOutputSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		option mcp.ToolOption
+		data   string // Path to the item properties: "" for objects, "data" for wrapped arrays
+	}{
+		{"object result", withOutputSchema[schemaItem](), ""},
+		{"array result is wrapped", withOutputSchema[[]schemaItem](), "data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := mcp.NewTool("test", tt.option)
+			schema := tool.OutputSchema
+			if schema.Type != "object" || schema.Required != nil || schema.AdditionalProperties != nil {
+				t.Fatalf("output schema root = %+v, want an open object without required keys", schema)
+			}
+
+			properties := schema.Properties
+			if tt.data != "" {
+				data, _ := properties[tt.data].(map[string]interface{})
+				// Nil slices marshal as null, so the generator makes them nullable.
+				if !reflect.DeepEqual(data["type"], []interface{}{"null", "array"}) {
+					t.Fatalf("%s = %v, want a nullable array", tt.data, properties[tt.data])
+				}
+				items, _ := data["items"].(map[string]interface{})
+				properties, _ = items["properties"].(map[string]interface{})
+			}
+			if _, ok := properties["id"]; !ok {
+				t.Fatalf("item properties = %v, want 'id'", properties)
+			}
+			tags, _ := properties["tags"].(map[string]interface{})
+			if !reflect.DeepEqual(tags["type"], []interface{}{"null", "object"}) {
+				t.Errorf("tags type = %v, want nullable object", tags["type"])
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/auth"
+	"xiaoyuzhoufm-mcp/internal/subscription"
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// subscriptionFetchLimit is how many of the newest episodes each poll compares against what
+// was seen before.
+const subscriptionFetchLimit = 20
+
+// accountContextFunc returns a long-lived context carrying the Xiaoyuzhou account of the
+// request in ctx, used to poll on behalf of a subscriber after the request has finished.
+type accountContextFunc func(ctx context.Context) (context.Context, error)
+
+// processAccountContext polls as the process-wide account.
+func processAccountContext(context.Context) (context.Context, error) {
+	return context.Background(), nil
+}
+
+// tenantAccountContext polls as the account bound to the subscribing principal.
+func tenantAccountContext(store *xyzclient.TenantStore) accountContextFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		// Detach from the subscribe request, which ends long before polling does.
+		return principalAccountContext(context.WithoutCancel(ctx), store)
+	}
+}
+
+// enableSubscriptions lets clients subscribe to xyz://podcast/{pid}/episodes and sends
+// notifications/resources/updated when the podcast publishes a new episode. Polling stops
+// when ctx is canceled.
+func enableSubscriptions(ctx context.Context, s *server.MCPServer, accountContext accountContextFunc) error {
+	statePath, err := subscription.GetDefaultStatePath()
+	if err != nil {
+		return err
+	}
+
+	fetch := func(ctx context.Context, podcastID string) ([]string, error) {
+		episodes, err := xyzclient.ListPodcastEpisodes(ctx, xyzclient.EpisodeListRequest{
+			PID:   podcastID,
+			Order: "desc",
+			Limit: subscriptionFetchLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		eids := make([]string, 0, len(episodes.Data))
+		for _, episode := range episodes.Data {
+			eids = append(eids, episode.EID)
+		}
+		return eids, nil
+	}
+
+	var manager *subscription.Manager
+	notify := func(sessionID, uri string) error {
+		err := s.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		if errors.Is(err, server.ErrSessionNotFound) {
+			manager.RemoveSession(sessionID)
+		}
+		return err
+	}
+
+	manager, err = subscription.NewManager(statePath, fetch, notify)
+	if err != nil {
+		return err
+	}
+
+	server.WithResourceCapabilities(true, false)(s)
+	withHooks(func(hooks *server.Hooks) {
+		// mcp-go answers resources/subscribe before the AfterSubscribe hook runs, so requests
+		// that can't be served are rejected here, where an error becomes a JSON-RPC error.
+		hooks.AddOnRequestInitialization(func(ctx context.Context, id any, message any) error {
+			raw, ok := message.(json.RawMessage)
+			if !ok {
+				return nil
+			}
+			var request mcp.SubscribeRequest
+			if err := json.Unmarshal(raw, &request); err != nil || request.Method != string(mcp.MethodResourcesSubscribe) {
+				return nil
+			}
+			return checkSubscription(ctx, request.Params.URI, accountContext)
+		})
+		hooks.AddAfterSubscribe(func(ctx context.Context, id any, message *mcp.SubscribeRequest, result *mcp.EmptyResult) {
+			session := server.ClientSessionFromContext(ctx)
+			if session == nil {
+				return
+			}
+			accountCtx, err := accountContext(ctx)
+			if err != nil {
+				slog.Warn("Ignored subscription without a usable Xiaoyuzhou account.", "session", session.SessionID(), "uri", message.Params.URI, "error", err)
+				return
+			}
+			if err := manager.Subscribe(session.SessionID(), message.Params.URI, accountCtx); err != nil {
+				slog.Warn("Ignored unsupported resource subscription.", "session", session.SessionID(), "error", err)
+			}
+		})
+		hooks.AddBeforeUnsubscribe(func(ctx context.Context, id any, message *mcp.UnsubscribeRequest) {
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				manager.Unsubscribe(session.SessionID(), message.Params.URI)
+			}
+		})
+		hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+			manager.RemoveSession(session.SessionID())
+		})
+	})(s)
+
+	go manager.Run(ctx)
+	return nil
+}
+
+// checkSubscription reports why a resources/subscribe request for uri can't be served: the
+// principal lacks the read scope, has no Xiaoyuzhou account to poll with, or uri is not a
+// subscribable resource.
+func checkSubscription(ctx context.Context, uri string, accountContext accountContextFunc) error {
+	if principal := auth.PrincipalFromContext(ctx); principal != nil && !principal.HasScope(auth.ScopeRead) {
+		return fmt.Errorf("principal %s lacks scope %s", principal.Name, auth.ScopeRead)
+	}
+	if _, ok := subscription.ParsePodcastEpisodesURI(uri); !ok {
+		return fmt.Errorf("subscriptions are only supported for xyz://podcast/{pid}/episodes, got %s", uri)
+	}
+	if _, err := accountContext(ctx); err != nil {
+		return err
+	}
+	return nil
+}
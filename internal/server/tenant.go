@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/auth"
+	"xiaoyuzhoufm-mcp/internal/tools"
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// tenantLoginTools can be called before the principal has a Xiaoyuzhou account bound. They
+// are write tools, so only principals with the xyz:write scope can bind or unbind accounts.
+var tenantLoginTools = map[string]bool{
+	"start_login":    true,
+	"complete_login": true,
+	"logout":         true,
+}
+
+// tenantOptions returns the MCP server options that make every tool call, resource read and
+// prompt act as the Xiaoyuzhou account bound to the calling principal.
+func tenantOptions(store *xyzclient.TenantStore) []server.ServerOption {
+	bindAccount := func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if tenantLoginTools[request.Params.Name] {
+				return next(ctx, request)
+			}
+			principal := auth.PrincipalFromContext(ctx)
+			if principal == nil {
+				return mcp.NewToolResultError("错误: 多租户模式下请求必须经过认证。"), nil
+			}
+			tm, err := store.TokenManager(principal.Name)
+			if err != nil {
+				if errors.Is(err, xyzclient.ErrNotLoggedIn) {
+					return mcp.NewToolResultError("当前身份尚未绑定小宇宙账号，请先调用 start_login 和 complete_login 登录。"), nil
+				}
+				slog.Error("Failed to load tenant token.", "principal", principal.Name, "error", err)
+				return mcp.NewToolResultErrorFromErr("加载当前身份的小宇宙令牌失败", err), nil
+			}
+			return next(xyzclient.WithTokenManager(ctx, tm), request)
+		}
+	}
+
+	bindResourceAccount := func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			accountCtx, err := principalAccountContext(ctx, store)
+			if err != nil {
+				return nil, err
+			}
+			return next(accountCtx, request)
+		}
+	}
+
+	bindPromptAccount := func(next server.PromptHandlerFunc) server.PromptHandlerFunc {
+		return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			accountCtx, err := principalAccountContext(ctx, store)
+			if err != nil {
+				return nil, err
+			}
+			return next(accountCtx, request)
+		}
+	}
+	return []server.ServerOption{
+		server.WithToolHandlerMiddleware(bindAccount),
+		server.WithResourceHandlerMiddleware(bindResourceAccount),
+		server.WithPromptHandlerMiddleware(bindPromptAccount),
+	}
+}
+
+// principalAccountContext returns ctx acting as the Xiaoyuzhou account bound to its principal.
+// It is used where errors are protocol errors rather than tool results.
+func principalAccountContext(ctx context.Context, store *xyzclient.TenantStore) (context.Context, error) {
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil {
+		return nil, fmt.Errorf("multi-tenant mode requires an authenticated request")
+	}
+	tm, err := store.TokenManager(principal.Name)
+	if err != nil {
+		if errors.Is(err, xyzclient.ErrNotLoggedIn) {
+			return nil, fmt.Errorf("principal %s has no Xiaoyuzhou account bound; call start_login and complete_login first", principal.Name)
+		}
+		return nil, err
+	}
+	return xyzclient.WithTokenManager(ctx, tm), nil
+}
+
+// registerTenantTools adds the per-principal login and logout tools.
+func registerTenantTools(s *server.MCPServer, store *xyzclient.TenantStore) {
+	startLoginTool := mcp.NewTool("start_login",
+		mcp.WithDescription("为当前身份绑定小宇宙账号的第一步：向该手机号发送短信验证码。收到验证码后调用 complete_login。"),
+		mcp.WithString("area_code",
+			mcp.Description("手机号区号，默认 +86。"),
+		),
+		mcp.WithString("phone_number",
+			mcp.Description("小宇宙账号绑定的手机号，仅数字。"),
+			mcp.Required(),
+		),
+		writeTool(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(startLoginTool, tools.StartLoginHandler)
+
+	completeLoginTool := mcp.NewTool("complete_login",
+		mcp.WithDescription("为当前身份绑定小宇宙账号的第二步：使用短信验证码登录。此后当前身份的所有工具调用都以该账号进行。"),
+		mcp.WithString("area_code",
+			mcp.Description("手机号区号，默认 +86，需与 start_login 一致。"),
+		),
+		mcp.WithString("phone_number",
+			mcp.Description("小宇宙账号绑定的手机号，需与 start_login 一致。"),
+			mcp.Required(),
+		),
+		mcp.WithString("verification_code",
+			mcp.Description("短信收到的 4 位验证码。"),
+			mcp.Required(),
+		),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.LoginResult](),
+	)
+	s.AddTool(completeLoginTool, tools.CompleteLoginHandler(store))
+
+	logoutTool := mcp.NewTool("logout",
+		mcp.WithDescription("退出当前身份绑定的小宇宙账号：在服务端注销会话并删除保存的令牌与缓存。"),
+		writeTool(),
+		withConfirm(),
+		withOutputSchema[tools.ActionResult](),
+	)
+	s.AddTool(logoutTool, tools.LogoutHandler(store))
+}
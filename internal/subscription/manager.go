@@ -0,0 +1,286 @@
+// Package subscription watches podcasts that MCP clients subscribed to and reports new episodes.
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Polling bounds. Each podcast starts at MinPollInterval; every poll that finds nothing new
+// (or fails) doubles its interval up to MaxPollInterval, and a new episode resets it.
+const (
+	MinPollInterval = 5 * time.Minute
+	MaxPollInterval = 2 * time.Hour
+	schedulerTick   = 15 * time.Second
+	pollTimeout     = 30 * time.Second
+)
+
+const (
+	podcastURIPrefix  = "xyz://podcast/"
+	episodesURISuffix = "/episodes"
+)
+
+// FetchFunc returns the EIDs of the newest episodes of a podcast, newest first.
+// ctx carries the account used for the API call.
+type FetchFunc func(ctx context.Context, podcastID string) ([]string, error)
+
+// NotifyFunc tells one session that the resource at uri has changed.
+type NotifyFunc func(sessionID, uri string) error
+
+// watchedPodcast is the polling schedule and subscriber list of one podcast.
+type watchedPodcast struct {
+	uri      string
+	interval time.Duration
+	nextPoll time.Time
+	polling  bool
+	// subscribers maps session IDs to the context whose account is used for polling.
+	subscribers map[string]context.Context
+}
+
+// Manager tracks resources/subscribe requests for xyz://podcast/{pid}/episodes and polls the
+// subscribed podcasts in the background.
+type Manager struct {
+	fetch     FetchFunc
+	notify    NotifyFunc
+	statePath string
+
+	mu       sync.Mutex
+	podcasts map[string]*watchedPodcast // Podcast ID -> schedule
+	state    map[string]*podcastState   // Podcast ID -> seen episodes, persisted
+}
+
+// NewManager creates a Manager persisting its dedup state at statePath.
+func NewManager(statePath string, fetch FetchFunc, notify NotifyFunc) (*Manager, error) {
+	state, err := loadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{
+		fetch:     fetch,
+		notify:    notify,
+		statePath: statePath,
+		podcasts:  make(map[string]*watchedPodcast),
+		state:     state,
+	}, nil
+}
+
+// ParsePodcastEpisodesURI extracts the podcast ID from an xyz://podcast/{pid}/episodes URI.
+func ParsePodcastEpisodesURI(uri string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, podcastURIPrefix)
+	if !ok {
+		return "", false
+	}
+	podcastID, ok := strings.CutSuffix(rest, episodesURISuffix)
+	if !ok || podcastID == "" || strings.Contains(podcastID, "/") {
+		return "", false
+	}
+	return podcastID, true
+}
+
+// Subscribe registers a session's interest in uri. accountCtx must outlive the request,
+// since it is used for background polling.
+func (m *Manager) Subscribe(sessionID, uri string, accountCtx context.Context) error {
+	podcastID, ok := ParsePodcastEpisodesURI(uri)
+	if !ok {
+		return fmt.Errorf("subscriptions are only supported for %s{pid}%s, got %s", podcastURIPrefix, episodesURISuffix, uri)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	podcast, ok := m.podcasts[podcastID]
+	if !ok {
+		podcast = &watchedPodcast{
+			uri:         uri,
+			interval:    MinPollInterval,
+			nextPoll:    time.Now(), // Poll right away to establish or refresh the baseline
+			subscribers: make(map[string]context.Context),
+		}
+		m.podcasts[podcastID] = podcast
+	}
+	podcast.subscribers[sessionID] = accountCtx
+	slog.Info("Subscribed to podcast episodes.", "session", sessionID, "podcast_id", podcastID, "subscribers", len(podcast.subscribers))
+	return nil
+}
+
+// Unsubscribe removes a session's interest in uri.
+func (m *Manager) Unsubscribe(sessionID, uri string) {
+	podcastID, ok := ParsePodcastEpisodesURI(uri)
+	if !ok {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removeSubscriberLocked(podcastID, sessionID)
+}
+
+// RemoveSession drops every subscription of a closed session.
+func (m *Manager) RemoveSession(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for podcastID := range m.podcasts {
+		m.removeSubscriberLocked(podcastID, sessionID)
+	}
+}
+
+func (m *Manager) removeSubscriberLocked(podcastID, sessionID string) {
+	podcast, ok := m.podcasts[podcastID]
+	if !ok {
+		return
+	}
+	if _, subscribed := podcast.subscribers[sessionID]; !subscribed {
+		return
+	}
+	delete(podcast.subscribers, sessionID)
+	slog.Info("Unsubscribed from podcast episodes.", "session", sessionID, "podcast_id", podcastID, "subscribers", len(podcast.subscribers))
+	if len(podcast.subscribers) == 0 {
+		delete(m.podcasts, podcastID)
+	}
+}
+
+// Run polls due podcasts until ctx is canceled.
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+	for {
+		for _, podcastID := range m.duePodcasts() {
+			m.poll(ctx, podcastID)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// duePodcasts returns the podcasts whose next poll is due and marks them as being polled.
+func (m *Manager) duePodcasts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	var due []string
+	for podcastID, podcast := range m.podcasts {
+		if !podcast.polling && !now.Before(podcast.nextPoll) {
+			podcast.polling = true
+			due = append(due, podcastID)
+		}
+	}
+	return due
+}
+
+// poll fetches the newest episodes of one podcast, records unseen ones and notifies subscribers.
+// Subscribers may poll as different accounts, so a failed fetch is retried as each of the other
+// subscribers before the podcast backs off.
+func (m *Manager) poll(ctx context.Context, podcastID string) {
+	m.mu.Lock()
+	podcast, ok := m.podcasts[podcastID]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	accountCtxs := make([]context.Context, 0, len(podcast.subscribers))
+	for _, subscriberCtx := range podcast.subscribers {
+		accountCtxs = append(accountCtxs, subscriberCtx)
+	}
+	m.mu.Unlock()
+
+	var latest []string
+	var err error
+	for i, accountCtx := range accountCtxs {
+		latest, err = m.fetchAs(ctx, accountCtx, podcastID)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		if i < len(accountCtxs)-1 {
+			slog.Debug("Failed to poll podcast episodes, retrying as another subscriber.", "podcast_id", podcastID, "error", err)
+		}
+	}
+
+	uri, sessionIDs := m.recordPoll(podcastID, latest, err)
+	for _, sessionID := range sessionIDs {
+		if err := m.notify(sessionID, uri); err != nil {
+			slog.Warn("Failed to notify subscriber.", "session", sessionID, "uri", uri, "error", err)
+		}
+	}
+}
+
+// fetchAs fetches the newest episodes of a podcast as the account carried by accountCtx.
+func (m *Manager) fetchAs(ctx, accountCtx context.Context, podcastID string) ([]string, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+	return m.fetch(mergeValues(fetchCtx, accountCtx), podcastID)
+}
+
+// recordPoll updates the schedule and dedup state with a poll result. It returns the resource
+// URI and the sessions to notify, which is empty unless new episodes were found.
+func (m *Manager) recordPoll(podcastID string, latest []string, pollErr error) (string, []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	podcast, ok := m.podcasts[podcastID]
+	if !ok {
+		return "", nil // Every subscriber left while polling
+	}
+	podcast.polling = false
+
+	if pollErr != nil {
+		podcast.interval = min(podcast.interval*2, MaxPollInterval)
+		podcast.nextPoll = time.Now().Add(podcast.interval)
+		slog.Warn("Failed to poll podcast episodes.", "podcast_id", podcastID, "retry_in", podcast.interval, "error", pollErr)
+		return "", nil
+	}
+
+	state, known := m.state[podcastID]
+	if !known {
+		state = &podcastState{}
+		m.state[podcastID] = state
+	}
+	fresh := state.mergeEpisodes(latest)
+	state.LastCheckedAt = time.Now()
+	if err := saveState(m.statePath, m.state); err != nil {
+		slog.Warn("Failed to persist subscription state.", "error", err)
+	}
+
+	switch {
+	case !known:
+		// The first poll of a never-seen podcast only establishes the baseline.
+		slog.Debug("Recorded baseline episodes for podcast.", "podcast_id", podcastID, "count", len(fresh))
+		podcast.nextPoll = time.Now().Add(podcast.interval)
+		return "", nil
+	case len(fresh) == 0:
+		podcast.interval = min(podcast.interval*2, MaxPollInterval)
+		podcast.nextPoll = time.Now().Add(podcast.interval)
+		slog.Debug("Polled podcast episodes, nothing new.", "podcast_id", podcastID, "next_in", podcast.interval)
+		return "", nil
+	}
+
+	podcast.interval = MinPollInterval
+	podcast.nextPoll = time.Now().Add(podcast.interval)
+	slog.Info("New podcast episodes found.", "podcast_id", podcastID, "eids", fresh, "subscribers", len(podcast.subscribers))
+	sessionIDs := make([]string, 0, len(podcast.subscribers))
+	for sessionID := range podcast.subscribers {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	return podcast.uri, sessionIDs
+}
+
+// valuesContext takes values from one context and cancellation from another, so that a poll
+// runs as the subscriber's account but stops when the manager shuts down.
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valuesContext) Value(key any) any {
+	return c.values.Value(key)
+}
+
+func mergeValues(ctx, values context.Context) context.Context {
+	if values == nil {
+		return ctx
+	}
+	return valuesContext{Context: ctx, values: values}
+}
@@ -0,0 +1,222 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParsePodcastEpisodesURI(t *testing.T) {
+	tests := []struct {
+		uri    string
+		wantID string
+		ok     bool
+	}{
+		{"xyz://podcast/abc123/episodes", "abc123", true},
+		{"xyz://podcast/abc123", "", false},
+		{"xyz://podcast//episodes", "", false},
+		{"xyz://podcast/a/b/episodes", "", false},
+		{"xyz://episode/abc123", "", false},
+		{"https://podcast/abc123/episodes", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		podcastID, ok := ParsePodcastEpisodesURI(tt.uri)
+		if podcastID != tt.wantID || ok != tt.ok {
+			t.Errorf("ParsePodcastEpisodesURI(%q) = %q, %v, want %q, %v", tt.uri, podcastID, ok, tt.wantID, tt.ok)
+		}
+	}
+}
+
+// pollResult is what the fake fetch returns for one poll.
+type pollResult struct {
+	eids []string
+	err  error
+}
+
+// testManager returns a manager whose fetch replays results and whose notifications are recorded.
+func testManager(t *testing.T, statePath string, results *[]pollResult, notified *[]string) *Manager {
+	t.Helper()
+	fetch := func(ctx context.Context, podcastID string) ([]string, error) {
+		if len(*results) == 0 {
+			t.Fatalf("unexpected poll of %s", podcastID)
+		}
+		result := (*results)[0]
+		*results = (*results)[1:]
+		return result.eids, result.err
+	}
+	notify := func(sessionID, uri string) error {
+		*notified = append(*notified, sessionID+" "+uri)
+		return nil
+	}
+	m, err := NewManager(statePath, fetch, notify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSubscribeRejectsUnsupportedURIs(t *testing.T) {
+	m := testManager(t, filepath.Join(t.TempDir(), stateFileName), &[]pollResult{}, &[]string{})
+	for _, uri := range []string{"xyz://episode/e1", "xyz://podcast/p1", "xyz://user/u1"} {
+		if err := m.Subscribe("s1", uri, context.Background()); err == nil {
+			t.Errorf("Subscribe(%q) succeeded", uri)
+		}
+	}
+	if len(m.podcasts) != 0 {
+		t.Errorf("watched podcasts = %v, want none", m.podcasts)
+	}
+}
+
+func TestPollBackoff(t *testing.T) {
+	const uri = "xyz://podcast/p1/episodes"
+	fail := errors.New("api down")
+	steps := []struct {
+		name         string
+		result       pollResult
+		wantInterval time.Duration
+		wantNotify   bool
+	}{
+		{"baseline", pollResult{eids: []string{"e1"}}, MinPollInterval, false},
+		{"nothing new", pollResult{eids: []string{"e1"}}, 2 * MinPollInterval, false},
+		{"nothing new again", pollResult{eids: []string{"e1"}}, 4 * MinPollInterval, false},
+		{"failure backs off too", pollResult{err: fail}, 8 * MinPollInterval, false},
+		{"failure", pollResult{err: fail}, 16 * MinPollInterval, false},
+		{"capped", pollResult{eids: []string{"e1"}}, MaxPollInterval, false},
+		{"stays capped", pollResult{eids: []string{"e1"}}, MaxPollInterval, false},
+		{"new episode resets", pollResult{eids: []string{"e2", "e1"}}, MinPollInterval, true},
+		{"same page is not announced twice", pollResult{eids: []string{"e2", "e1"}}, 2 * MinPollInterval, false},
+	}
+
+	var results []pollResult
+	var notified []string
+	m := testManager(t, filepath.Join(t.TempDir(), stateFileName), &results, &notified)
+	if err := m.Subscribe("s1", uri, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range steps {
+		results = append(results, step.result)
+		notified = nil
+		before := time.Now()
+		m.poll(context.Background(), "p1")
+
+		podcast := m.podcasts["p1"]
+		if podcast.interval != step.wantInterval {
+			t.Errorf("%s: interval = %v, want %v", step.name, podcast.interval, step.wantInterval)
+		}
+		if podcast.nextPoll.Before(before.Add(step.wantInterval)) || podcast.polling {
+			t.Errorf("%s: next poll at %v (polling %v), want %v after the poll", step.name, podcast.nextPoll, podcast.polling, step.wantInterval)
+		}
+		if (len(notified) > 0) != step.wantNotify {
+			t.Errorf("%s: notified %v, want notification %v", step.name, notified, step.wantNotify)
+		}
+	}
+}
+
+func TestPollNotifiesEverySubscriber(t *testing.T) {
+	const uri = "xyz://podcast/p1/episodes"
+	statePath := filepath.Join(t.TempDir(), stateFileName)
+	results := []pollResult{{eids: []string{"e1"}}, {eids: []string{"e2", "e1"}}}
+	var notified []string
+	m := testManager(t, statePath, &results, &notified)
+	for _, sessionID := range []string{"s1", "s2", "s3"} {
+		if err := m.Subscribe(sessionID, uri, context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.Unsubscribe("s3", uri)
+
+	m.poll(context.Background(), "p1")
+	m.poll(context.Background(), "p1")
+	sort.Strings(notified)
+	if want := []string{"s1 " + uri, "s2 " + uri}; !slices.Equal(notified, want) {
+		t.Errorf("notified = %v, want %v", notified, want)
+	}
+
+	m.RemoveSession("s1")
+	m.RemoveSession("s2")
+	if len(m.podcasts) != 0 {
+		t.Errorf("podcast still watched after every session left")
+	}
+}
+
+func TestPollDedupAcrossRestarts(t *testing.T) {
+	const uri = "xyz://podcast/p1/episodes"
+	statePath := filepath.Join(t.TempDir(), stateFileName)
+
+	results := []pollResult{{eids: []string{"e1"}}, {eids: []string{"e2", "e1"}}}
+	var notified []string
+	first := testManager(t, statePath, &results, &notified)
+	first.Subscribe("s1", uri, context.Background())
+	first.poll(context.Background(), "p1")
+	first.poll(context.Background(), "p1")
+	if len(notified) != 1 {
+		t.Fatalf("first run notified %v, want one notification", notified)
+	}
+
+	// After a restart the known episodes are loaded, so the same page announces nothing and
+	// only a newer episode is reported.
+	results = []pollResult{{eids: []string{"e2", "e1"}}, {eids: []string{"e3", "e2", "e1"}}}
+	notified = nil
+	second := testManager(t, statePath, &results, &notified)
+	second.Subscribe("s2", uri, context.Background())
+	second.poll(context.Background(), "p1")
+	if len(notified) != 0 {
+		t.Errorf("restart re-announced known episodes: %v", notified)
+	}
+	second.poll(context.Background(), "p1")
+	if want := []string{"s2 " + uri}; !slices.Equal(notified, want) {
+		t.Errorf("notified = %v, want %v", notified, want)
+	}
+}
+
+type accountKey struct{}
+
+func TestPollFailsOverToOtherSubscribers(t *testing.T) {
+	const uri = "xyz://podcast/p1/episodes"
+	tests := []struct {
+		name         string
+		accounts     map[string]string // Session ID -> account
+		working      string            // Account whose fetch succeeds
+		wantAttempts int               // 0 when it depends on which subscriber is tried first
+		wantInterval time.Duration
+	}{
+		{"one failing subscriber backs off", map[string]string{"s1": "expired"}, "valid", 1, 2 * MinPollInterval},
+		{"another subscriber succeeds", map[string]string{"s1": "expired", "s2": "valid"}, "valid", 0, MinPollInterval},
+		{"every subscriber fails", map[string]string{"s1": "expired", "s2": "revoked", "s3": "banned"}, "valid", 3, 2 * MinPollInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			fetch := func(ctx context.Context, podcastID string) ([]string, error) {
+				attempts++
+				if ctx.Value(accountKey{}) != tt.working {
+					return nil, errors.New("token expired")
+				}
+				return []string{"e1"}, nil
+			}
+			m, err := NewManager(filepath.Join(t.TempDir(), stateFileName), fetch, func(string, string) error { return nil })
+			if err != nil {
+				t.Fatal(err)
+			}
+			for sessionID, account := range tt.accounts {
+				m.Subscribe(sessionID, uri, context.WithValue(context.Background(), accountKey{}, account))
+			}
+
+			m.poll(context.Background(), "p1")
+			if tt.wantAttempts > 0 && attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if got := m.podcasts["p1"].interval; got != tt.wantInterval {
+				t.Errorf("interval = %v, want %v", got, tt.wantInterval)
+			}
+			if _, baseline := m.state["p1"]; baseline != (tt.wantInterval == MinPollInterval) {
+				t.Errorf("baseline recorded = %v", baseline)
+			}
+		})
+	}
+}
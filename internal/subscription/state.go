@@ -0,0 +1,91 @@
+package subscription
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	stateFileName = "subscriptions.json"
+	// maxKnownEpisodes bounds how many EIDs are remembered per podcast. It only needs to
+	// exceed what one poll returns, since older episodes never reappear at the top.
+	maxKnownEpisodes = 100
+)
+
+// podcastState is what has already been seen of a podcast, persisted so that a restart
+// does not announce the same episodes again.
+type podcastState struct {
+	KnownEIDs     []string  `json:"knownEids"` // Newest first
+	LastCheckedAt time.Time `json:"lastCheckedAt"`
+}
+
+// GetDefaultStatePath returns the default path of the persisted subscription state.
+// Path is typically ~/.mcp/xiaoyuzhoufm-mcp/subscriptions.json
+func GetDefaultStatePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".mcp", "xiaoyuzhoufm-mcp", stateFileName), nil
+}
+
+// loadState reads the state file. A missing file yields an empty state.
+func loadState(path string) (map[string]*podcastState, error) {
+	state := make(map[string]*podcastState)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subscription state %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscription state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveState writes the state file atomically, so a crash cannot leave it half written.
+func saveState(path string, state map[string]*podcastState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write subscription state %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace subscription state %s: %w", path, err)
+	}
+	return nil
+}
+
+// mergeEpisodes returns the EIDs in latest that were not known yet and records them as known.
+func (ps *podcastState) mergeEpisodes(latest []string) []string {
+	known := make(map[string]bool, len(ps.KnownEIDs))
+	for _, eid := range ps.KnownEIDs {
+		known[eid] = true
+	}
+	var fresh []string
+	for _, eid := range latest {
+		if !known[eid] {
+			fresh = append(fresh, eid)
+		}
+	}
+	if len(fresh) > 0 {
+		ps.KnownEIDs = append(fresh, ps.KnownEIDs...)
+		if len(ps.KnownEIDs) > maxKnownEpisodes {
+			ps.KnownEIDs = ps.KnownEIDs[:maxKnownEpisodes]
+		}
+	}
+	return fresh
+}
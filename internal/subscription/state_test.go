@@ -0,0 +1,121 @@
+package subscription
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMergeEpisodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		known     []string
+		latest    []string
+		wantFresh []string
+		wantKnown []string
+	}{
+		{"first poll", nil, []string{"e2", "e1"}, []string{"e2", "e1"}, []string{"e2", "e1"}},
+		{"nothing new", []string{"e2", "e1"}, []string{"e2", "e1"}, nil, []string{"e2", "e1"}},
+		{"new episode on top", []string{"e2", "e1"}, []string{"e3", "e2", "e1"}, []string{"e3"}, []string{"e3", "e2", "e1"}},
+		{"known episode dropped from the page", []string{"e3", "e2", "e1"}, []string{"e4", "e3"}, []string{"e4"}, []string{"e4", "e3", "e2", "e1"}},
+		{"empty page", []string{"e1"}, nil, nil, []string{"e1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &podcastState{KnownEIDs: tt.known}
+			fresh := state.mergeEpisodes(tt.latest)
+			if !slices.Equal(fresh, tt.wantFresh) {
+				t.Errorf("fresh = %v, want %v", fresh, tt.wantFresh)
+			}
+			if !slices.Equal(state.KnownEIDs, tt.wantKnown) {
+				t.Errorf("known = %v, want %v", state.KnownEIDs, tt.wantKnown)
+			}
+			// Merging the same page again finds nothing.
+			if again := state.mergeEpisodes(tt.latest); len(again) != 0 {
+				t.Errorf("second merge found %v again", again)
+			}
+		})
+	}
+}
+
+func TestMergeEpisodesKeepsNewestKnown(t *testing.T) {
+	state := &podcastState{}
+	for i := range maxKnownEpisodes + 10 {
+		state.mergeEpisodes([]string{fmt.Sprintf("e%d", i)})
+	}
+	if len(state.KnownEIDs) != maxKnownEpisodes {
+		t.Fatalf("len(known) = %d, want %d", len(state.KnownEIDs), maxKnownEpisodes)
+	}
+	if newest := fmt.Sprintf("e%d", maxKnownEpisodes+9); state.KnownEIDs[0] != newest {
+		t.Errorf("known[0] = %s, want %s", state.KnownEIDs[0], newest)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	checked := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		state map[string]*podcastState
+	}{
+		{"empty", map[string]*podcastState{}},
+		{"podcasts", map[string]*podcastState{
+			"p1": {KnownEIDs: []string{"e2", "e1"}, LastCheckedAt: checked},
+			"p2": {KnownEIDs: []string{}, LastCheckedAt: checked},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "nested", stateFileName)
+			if err := saveState(path, tt.state); err != nil {
+				t.Fatalf("saveState: %v", err)
+			}
+			if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+				t.Errorf("temporary file left behind: %v", err)
+			}
+			loaded, err := loadState(path)
+			if err != nil {
+				t.Fatalf("loadState: %v", err)
+			}
+			if len(loaded) != len(tt.state) {
+				t.Fatalf("loaded %d podcasts, want %d", len(loaded), len(tt.state))
+			}
+			for podcastID, want := range tt.state {
+				got := loaded[podcastID]
+				if got == nil || !slices.Equal(got.KnownEIDs, want.KnownEIDs) || !got.LastCheckedAt.Equal(want.LastCheckedAt) {
+					t.Errorf("loaded[%s] = %+v, want %+v", podcastID, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadState(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"missing file is an empty state", filepath.Join(dir, "missing.json"), false},
+		{"corrupt file", corrupt, true},
+		{"directory instead of a file", dir, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := loadState(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadState error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(state) != 0 {
+				t.Errorf("loadState = %v, want an empty state", state)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"fmt"
+	"time"
+)
+
+// shanghaiLocation is used to interpret bare dates, matching the Timezone header sent to the API.
+var shanghaiLocation = time.FixedZone("Asia/Shanghai", 8*60*60)
+
+// intArg reads an optional integer argument. JSON numbers arrive as float64.
+// The result is clamped to [1, maxValue]; defaultValue is used when the argument is absent.
+func intArg(arguments map[string]interface{}, name string, defaultValue, maxValue int) int {
+	raw, ok := arguments[name].(float64)
+	if !ok {
+		return defaultValue
+	}
+	value := int(raw)
+	if value < 1 {
+		return 1
+	}
+	if value > maxValue {
+		return maxValue
+	}
+	return value
+}
+
+// stringSliceArg reads an optional array-of-strings argument, skipping empty and non-string entries.
+func stringSliceArg(arguments map[string]interface{}, name string) []string {
+	rawList, ok := arguments[name].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(rawList))
+	for _, raw := range rawList {
+		if s, ok := raw.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// parseDateArg parses a date argument given either as RFC 3339 or as YYYY-MM-DD (Asia/Shanghai).
+func parseDateArg(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, shanghaiLocation); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("无法解析日期 '%s'，请使用 YYYY-MM-DD 或 RFC 3339 格式", value)
+}
+
+// isOnOrAfter reports whether the API date string is at or after since.
+// Unparseable dates are treated as matching so that nothing is silently dropped.
+func isOnOrAfter(apiDate string, since time.Time) bool {
+	t, err := time.Parse(time.RFC3339, apiDate)
+	if err != nil {
+		return true
+	}
+	return !t.Before(since)
+}
@@ -0,0 +1,16 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// requireConfirmation guards tools that change the user's account. It returns an error
+// result unless the caller explicitly passed confirm=true, and nil when the call may proceed.
+func requireConfirmation(request mcp.CallToolRequest, action string) *mcp.CallToolResult {
+	if confirmed, ok := request.GetArguments()["confirm"].(bool); ok && confirmed {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("操作“%s”会修改用户的小宇宙账户数据。请先征得用户同意，然后将参数 'confirm' 设置为 true 再次调用。", action))
+}
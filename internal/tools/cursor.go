@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"hash/fnv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cursorVersion is bumped whenever the cursor payload changes, so that cursors issued by an
+// older server are rejected instead of misread.
+const cursorVersion = 1
+
+// cursorPayload is what a cursor encodes: the API's own paging key, plus the tool and query it
+// belongs to so that it can't be replayed against another list.
+type cursorPayload struct {
+	Version int             `json:"v"`
+	Tool    string          `json:"t"`
+	Scope   string          `json:"s,omitempty"`
+	Key     json.RawMessage `json:"k"`
+}
+
+// Page is the pagination state embedded in the result of every paged tool. The API's paging
+// keys differ per endpoint, so they are only ever exposed as an opaque cursor.
+type Page struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Total      *int   `json:"total,omitempty"`
+}
+
+// cursorScope identifies the query a cursor pages through, e.g. the podcast ID and order of an
+// episode list. Parameters that only filter a fetched page don't belong in the scope.
+func cursorScope(parts ...string) string {
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newPage returns the pagination state for the next page after key, the API's paging key as
+// returned with the current page. A nil key means there are no more pages.
+func newPage(request mcp.CallToolRequest, scope string, key interface{}) (Page, error) {
+	if key == nil {
+		return Page{}, nil
+	}
+	cursor, err := encodeCursor(request.Params.Name, scope, key)
+	if err != nil {
+		return Page{}, err
+	}
+	return Page{NextCursor: cursor, HasMore: true}, nil
+}
+
+// cursorArg decodes the 'cursor' argument into key, which must be a pointer to the API paging
+// key of the tool. key is left unchanged when no cursor is given. Errors are meant to be shown
+// after "输入参数 'cursor' ".
+func cursorArg(request mcp.CallToolRequest, scope string, key interface{}) error {
+	cursor, _ := request.GetArguments()["cursor"].(string)
+	// Cursors copied from a Markdown result may keep their code span backticks.
+	if cursor = strings.Trim(cursor, "` \t\r\n"); cursor == "" {
+		return nil
+	}
+	payload, err := decodeCursor(cursor)
+	if err != nil {
+		return err
+	}
+	switch {
+	case payload.Version != cursorVersion:
+		return errors.New("已过期，请重新从第一页开始查询")
+	case payload.Tool != request.Params.Name:
+		return errors.New("来自其他工具，只能传入本工具上一页结果中的 next_cursor")
+	case payload.Scope != scope:
+		return errors.New("与本次的查询参数不一致，翻页时请保持其他参数不变")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload.Key))
+	decoder.UseNumber() // Numeric keys such as timestamps must be passed back exactly
+	if err := decoder.Decode(key); err != nil {
+		return errors.New("无法解析，请原样传入上一页结果中的 next_cursor")
+	}
+	return nil
+}
+
+// encodeCursor encodes a paging key as URL-safe base64 of the JSON payload followed by its
+// CRC-32, so that a truncated or mistyped cursor is detected.
+func encodeCursor(tool, scope string, key interface{}) (string, error) {
+	rawKey, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(cursorPayload{Version: cursorVersion, Tool: tool, Scope: scope, Key: rawKey})
+	if err != nil {
+		return "", err
+	}
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string) (*cursorPayload, error) {
+	invalid := errors.New("无效，请原样传入上一页结果中的 next_cursor")
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(data) <= crc32.Size {
+		return nil, invalid
+	}
+	body, sum := data[:len(data)-crc32.Size], data[len(data)-crc32.Size:]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(sum) {
+		return nil, invalid
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, invalid
+	}
+	return &payload, nil
+}
@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func cursorRequest(tool, cursor string) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Name = tool
+	request.Params.Arguments = map[string]interface{}{"cursor": cursor}
+	return request
+}
+
+// rawCursor encodes payload the way encodeCursor does, so that tests can forge cursors.
+func rawCursor(t *testing.T, payload cursorPayload) string {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	scope := cursorScope("pid", "desc")
+	tests := []struct {
+		name string
+		key  interface{}
+		want string // JSON of the decoded key
+	}{
+		{"string key", "abc", `"abc"`},
+		{"object key", map[string]interface{}{"id": "e1", "direction": "NEXT"}, `{"direction":"NEXT","id":"e1"}`},
+		{"large number", json.Number("1700000000123456789"), `1700000000123456789`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := newPage(cursorRequest("list_podcast_episodes", ""), scope, tt.key)
+			if err != nil {
+				t.Fatalf("newPage: %v", err)
+			}
+			if !page.HasMore || page.NextCursor == "" {
+				t.Fatalf("newPage = %+v, want a next cursor", page)
+			}
+
+			for _, cursor := range []string{page.NextCursor, "`" + page.NextCursor + "`\n"} {
+				var key interface{}
+				if err := cursorArg(cursorRequest("list_podcast_episodes", cursor), scope, &key); err != nil {
+					t.Fatalf("cursorArg(%q): %v", cursor, err)
+				}
+				got, _ := json.Marshal(key)
+				if string(got) != tt.want {
+					t.Errorf("cursorArg(%q) key = %s, want %s", cursor, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPageWithoutKey(t *testing.T) {
+	page, err := newPage(cursorRequest("list_inbox", ""), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page != (Page{}) {
+		t.Errorf("newPage(nil) = %+v, want the zero Page", page)
+	}
+}
+
+func TestCursorArgRejects(t *testing.T) {
+	scope := cursorScope("pid", "desc")
+	valid, err := encodeCursor("list_podcast_episodes", scope, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		tool    string
+		scope   string
+		cursor  string
+		wantErr string
+	}{
+		{"not base64", "list_podcast_episodes", scope, "!!!", "无效"},
+		{"too short", "list_podcast_episodes", scope, "AAAA", "无效"},
+		{"truncated", "list_podcast_episodes", scope, valid[:len(valid)-2], "无效"},
+		{"corrupted", "list_podcast_episodes", scope, valid[:10] + flipBase64(valid[10:11]) + valid[11:], "无效"},
+		{"not JSON", "list_podcast_episodes", scope, base64.RawURLEncoding.EncodeToString(binary.BigEndian.AppendUint32([]byte("xyz"), crc32.ChecksumIEEE([]byte("xyz")))), "无效"},
+		{"old version", "list_podcast_episodes", scope, rawCursor(t, cursorPayload{Version: cursorVersion + 1, Tool: "list_podcast_episodes", Scope: scope, Key: json.RawMessage(`"abc"`)}), "已过期"},
+		{"other tool", "list_inbox", scope, valid, "来自其他工具"},
+		{"other scope", "list_podcast_episodes", cursorScope("pid", "asc"), valid, "查询参数不一致"},
+		{"key of another type", "list_podcast_episodes", scope, rawCursor(t, cursorPayload{Version: cursorVersion, Tool: "list_podcast_episodes", Scope: scope, Key: json.RawMessage(`{"id":1}`)}), "无法解析"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := "unchanged"
+			err := cursorArg(cursorRequest(tt.tool, tt.cursor), tt.scope, &key)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("cursorArg error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCursorArgWithoutCursor(t *testing.T) {
+	for _, cursor := range []string{"", "  ", "``"} {
+		key := "unchanged"
+		if err := cursorArg(cursorRequest("list_inbox", cursor), "", &key); err != nil {
+			t.Errorf("cursorArg(%q): %v", cursor, err)
+		}
+		if key != "unchanged" {
+			t.Errorf("cursorArg(%q) changed key to %q", cursor, key)
+		}
+	}
+}
+
+func TestCursorScope(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		same bool
+	}{
+		{"same parts", []string{"p1", "desc"}, []string{"p1", "desc"}, true},
+		{"different order value", []string{"p1", "desc"}, []string{"p1", "asc"}, false},
+		{"parts are not concatenated", []string{"ab", "c"}, []string{"a", "bc"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cursorScope(tt.a...) == cursorScope(tt.b...); got != tt.same {
+				t.Errorf("cursorScope(%q) == cursorScope(%q) is %v, want %v", tt.a, tt.b, got, tt.same)
+			}
+		})
+	}
+}
+
+// flipBase64 changes one base64 character to another, which alters the decoded bytes.
+func flipBase64(c string) string {
+	if c == "A" {
+		return "B"
+	}
+	return "A"
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CategoryPodcastsResult is the result of the browse_category tool.
+type CategoryPodcastsResult struct {
+	CategoryID string            `json:"categoryId"`
+	Data       []PodcastOverview `json:"data"`
+	Page
+}
+
+// DiscoveryItem is one entry of the get_discovery_feed result.
+type DiscoveryItem struct {
+	Type           string           `json:"type"`
+	Title          string           `json:"title,omitempty"`
+	Recommendation string           `json:"recommendation,omitempty"`
+	Episode        *EpisodeSummary  `json:"episode,omitempty"`
+	Podcast        *PodcastOverview `json:"podcast,omitempty"`
+}
+
+// DiscoveryFeedResult is the result of the get_discovery_feed tool.
+type DiscoveryFeedResult struct {
+	Data []DiscoveryItem `json:"data"`
+	Page
+}
+
+// ListCategoriesHandler is the MCP handler function for the list_categories tool.
+func ListCategoriesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_categories tool", "arguments", request.Params.Arguments)
+
+	categories, err := xyzclient.ListCategories(ctx)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取分类列表失败", err), nil
+	}
+
+	slog.Debug("成功获取分类列表", "count", len(categories))
+	return newToolResult(request, categories), nil
+}
+
+// BrowseCategoryHandler is the MCP handler function for the browse_category tool.
+func BrowseCategoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing browse_category tool", "arguments", request.Params.Arguments)
+
+	categoryID, ok := request.GetArguments()["category_id"].(string)
+	if !ok || categoryID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'category_id' 不能为空且必须是字符串类型。"), nil
+	}
+
+	apiRequest := xyzclient.CategoryPodcastListRequest{
+		CategoryID: categoryID,
+		Limit:      intArg(request.GetArguments(), "limit", 20, 50),
+	}
+	scope := cursorScope(categoryID)
+	if err := cursorArg(request, scope, &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	podcastsData, err := xyzclient.ListCategoryPodcasts(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取分类播客列表失败", err), nil
+	}
+
+	result := CategoryPodcastsResult{
+		CategoryID: categoryID,
+		Data:       make([]PodcastOverview, 0, len(podcastsData.Data)),
+	}
+	for i := range podcastsData.Data {
+		result.Data = append(result.Data, newPodcastOverview(&podcastsData.Data[i]))
+	}
+
+	if result.Page, err = newPage(request, scope, podcastsData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取分类播客列表", "category_id", categoryID, "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
+
+// GetDiscoveryFeedHandler is the MCP handler function for the get_discovery_feed tool.
+func GetDiscoveryFeedHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing get_discovery_feed tool", "arguments", request.Params.Arguments)
+
+	apiRequest := xyzclient.DiscoveryFeedRequest{}
+	if err := cursorArg(request, "", &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	feedData, err := xyzclient.ListDiscoveryFeed(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取发现页推荐失败", err), nil
+	}
+
+	result := DiscoveryFeedResult{
+		Data: make([]DiscoveryItem, 0, len(feedData.Data)),
+	}
+	for i := range feedData.Data {
+		entry := &feedData.Data[i]
+		item := DiscoveryItem{
+			Type:           entry.Type,
+			Title:          entry.Title,
+			Recommendation: entry.Recommendation,
+		}
+		if entry.Episode != nil {
+			summary := newEpisodeSummary(entry.Episode)
+			item.Episode = &summary
+		}
+		if entry.Podcast != nil {
+			podcast := newPodcastOverview(entry.Podcast)
+			item.Podcast = &podcast
+		}
+		// Skip modules this server does not know how to summarize (banners, ads, etc.).
+		if item.Episode == nil && item.Podcast == nil {
+			continue
+		}
+		result.Data = append(result.Data, item)
+	}
+
+	if result.Page, err = newPage(request, "", feedData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取发现页推荐", "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
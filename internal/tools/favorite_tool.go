@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FavoriteListResult is the result of the list_favorites tool.
+type FavoriteListResult struct {
+	Data []xyzclient.FavoriteItem `json:"data"`
+	Page
+}
+
+// ListFavoritesHandler is the MCP handler function for the list_favorites tool.
+func ListFavoritesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_favorites tool", "arguments", request.Params.Arguments)
+
+	apiRequest := xyzclient.FavoriteListRequest{
+		Limit: intArg(request.GetArguments(), "limit", 20, 50),
+	}
+	if err := cursorArg(request, "", &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	favoritesData, err := xyzclient.ListFavoriteEpisodes(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取收藏列表失败", err), nil
+	}
+	for i := range favoritesData.Data {
+		redactLockedMedia(&favoritesData.Data[i].Episode)
+	}
+
+	result := FavoriteListResult{Data: favoritesData.Data}
+	if result.Page, err = newPage(request, "", favoritesData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取收藏列表", "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
+
+// SetEpisodeFavoriteHandler is the MCP handler function for the set_episode_favorite tool.
+func SetEpisodeFavoriteHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing set_episode_favorite tool", "arguments", request.Params.Arguments)
+
+	episodeID, ok := request.GetArguments()["episode_id"].(string)
+	if !ok || episodeID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'episode_id' 不能为空且必须是字符串类型。"), nil
+	}
+	favorited, ok := request.GetArguments()["favorited"].(bool)
+	if !ok {
+		return mcp.NewToolResultError("错误: 输入参数 'favorited' 必须是布尔类型。"), nil
+	}
+	if result := requireConfirmation(request, "收藏/取消收藏单集"); result != nil {
+		return result, nil
+	}
+
+	if err := xyzclient.SetEpisodeFavorite(ctx, episodeID, favorited); err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API更新收藏状态失败", err), nil
+	}
+
+	slog.Info("Episode favorite status updated", "episode_id", episodeID, "favorited", favorited)
+	if favorited {
+		return newActionResult("已收藏单集 " + episodeID + "。"), nil
+	}
+	return newActionResult("已取消收藏单集 " + episodeID + "。"), nil
+}
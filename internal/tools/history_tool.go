@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxProgressEpisodes limits how many episodes get_playback_progress looks up in one call,
+// since each episode needs its own details request for duration and title.
+const maxProgressEpisodes = 20
+
+// progressFetchWorkers is how many episode details requests get_playback_progress runs at once.
+const progressFetchWorkers = 4
+
+// EpisodeProgress combines an episode's saved playback position with its completion state.
+type EpisodeProgress struct {
+	EID             string  `json:"eid"`
+	Title           string  `json:"title"`
+	PodcastTitle    string  `json:"podcastTitle"`
+	Position        int     `json:"position"` // Seconds
+	Duration        int     `json:"duration"` // Seconds
+	PercentComplete float64 `json:"percentComplete"`
+	IsPlayed        bool    `json:"isPlayed"`
+	IsFinished      bool    `json:"isFinished"`
+}
+
+// HistoryListResult is the result of the list_listening_history tool.
+type HistoryListResult struct {
+	Data []xyzclient.PlayedHistoryItem `json:"data"`
+	Page
+}
+
+// ListListeningHistoryHandler is the MCP handler function for the list_listening_history tool.
+func ListListeningHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_listening_history tool", "arguments", request.Params.Arguments)
+
+	apiRequest := xyzclient.PlayedHistoryRequest{
+		Limit: intArg(request.GetArguments(), "limit", 20, 50),
+	}
+	if err := cursorArg(request, "", &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	historyData, err := xyzclient.ListPlayedHistory(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取收听历史失败", err), nil
+	}
+
+	if sinceArg, ok := request.GetArguments()["since"].(string); ok && sinceArg != "" {
+		since, err := parseDateArg(sinceArg)
+		if err != nil {
+			return mcp.NewToolResultError("错误: 输入参数 'since' " + err.Error()), nil
+		}
+		filtered := make([]xyzclient.PlayedHistoryItem, 0, len(historyData.Data))
+		for _, item := range historyData.Data {
+			if isOnOrAfter(item.PlayedAt, since) {
+				filtered = append(filtered, item)
+			}
+		}
+		// History is newest first, so once items fall before 'since' no further page is relevant.
+		if len(filtered) < len(historyData.Data) {
+			historyData.LoadMoreKey = nil
+		}
+		historyData.Data = filtered
+	}
+	for i := range historyData.Data {
+		redactLockedMedia(&historyData.Data[i].Episode)
+	}
+
+	result := HistoryListResult{Data: historyData.Data}
+	if result.Page, err = newPage(request, "", historyData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取收听历史", "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
+
+// GetPlaybackProgressHandler is the MCP handler function for the get_playback_progress tool.
+func GetPlaybackProgressHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing get_playback_progress tool", "arguments", request.Params.Arguments)
+
+	episodeIDs := stringSliceArg(request.GetArguments(), "episode_ids")
+	if len(episodeIDs) == 0 {
+		return mcp.NewToolResultError("错误: 输入参数 'episode_ids' 不能为空且必须是字符串数组。"), nil
+	}
+	if len(episodeIDs) > maxProgressEpisodes {
+		return mcp.NewToolResultError(fmt.Sprintf("错误: 'episode_ids' 最多支持 %d 个单集。", maxProgressEpisodes)), nil
+	}
+
+	progressList, err := xyzclient.GetPlaybackProgress(ctx, episodeIDs)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取播放进度失败", err), nil
+	}
+	positionByEID := make(map[string]int, len(progressList))
+	for _, p := range progressList {
+		positionByEID[p.EID] = p.Progress
+	}
+
+	episodes, err := fetchEpisodeDetails(ctx, episodeIDs)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取单集详情失败", err), nil
+	}
+	result := make([]EpisodeProgress, 0, len(episodeIDs))
+	for i, eid := range episodeIDs {
+		result = append(result, newEpisodeProgress(episodes[i], positionByEID[eid]))
+	}
+
+	slog.Debug("成功获取播放进度", "count", len(result))
+	return newToolResult(request, result), nil
+}
+
+// fetchEpisodeDetails looks up the details of several episodes, progressFetchWorkers at a time,
+// since the API has no batch endpoint for them. Results are in the order of episodeIDs; the
+// first failure cancels the remaining requests.
+func fetchEpisodeDetails(ctx context.Context, episodeIDs []string) ([]*xyzclient.Episode, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	episodes := make([]*xyzclient.Episode, len(episodeIDs))
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	slots := make(chan struct{}, progressFetchWorkers)
+	for i, eid := range episodeIDs {
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+			episode, err := xyzclient.GetEpisodeDetailsByID(ctx, eid)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("episode %s: %w", eid, err)
+					cancel()
+				})
+				return
+			}
+			episodes[i] = episode
+		})
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // The call was canceled before every episode was fetched
+	}
+	return episodes, nil
+}
+
+// ListInProgressEpisodesHandler is the MCP handler function for the list_in_progress_episodes tool.
+func ListInProgressEpisodesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_in_progress_episodes tool", "arguments", request.Params.Arguments)
+
+	limit := intArg(request.GetArguments(), "limit", 10, 50)
+
+	inProgress, err := xyzclient.ListInProgressEpisodes(ctx, limit)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取未听完单集失败", err), nil
+	}
+
+	result := make([]EpisodeProgress, 0, len(inProgress))
+	for i := range inProgress {
+		result = append(result, newEpisodeProgress(&inProgress[i].Episode, inProgress[i].Progress))
+	}
+
+	slog.Debug("成功获取未听完单集", "count", len(result))
+	return newToolResult(request, result), nil
+}
+
+func newEpisodeProgress(episode *xyzclient.Episode, position int) EpisodeProgress {
+	progress := EpisodeProgress{
+		EID:          episode.EID,
+		Title:        episode.Title,
+		PodcastTitle: episode.Podcast.Title,
+		Position:     position,
+		Duration:     episode.Duration,
+		IsPlayed:     episode.IsPlayed,
+		IsFinished:   episode.IsFinished,
+	}
+	switch {
+	case episode.IsFinished:
+		progress.PercentComplete = 100
+	case episode.Duration > 0:
+		progress.PercentComplete = float64(int(float64(position)/float64(episode.Duration)*1000)) / 10
+	}
+	return progress
+}
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxInboxPages bounds how many inbox pages one get_inbox call reads when filters drop episodes.
+const maxInboxPages = 5
+
+// InboxResult is the result of the get_inbox tool.
+type InboxResult struct {
+	Data []EpisodeSummary `json:"data"`
+	Page
+}
+
+// GetInboxHandler is the MCP handler function for the get_inbox tool.
+func GetInboxHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing get_inbox tool", "arguments", request.Params.Arguments)
+
+	limit := intArg(request.GetArguments(), "limit", 20, 50)
+	unplayedOnly, _ := request.GetArguments()["unplayed_only"].(bool)
+
+	var since time.Time
+	if sinceArg, ok := request.GetArguments()["since"].(string); ok && sinceArg != "" {
+		parsed, err := parseDateArg(sinceArg)
+		if err != nil {
+			return mcp.NewToolResultError("错误: 输入参数 'since' " + err.Error()), nil
+		}
+		since = parsed
+	}
+
+	apiRequest := xyzclient.InboxListRequest{Limit: limit}
+	if err := cursorArg(request, "", &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	// Whole pages are always consumed so that the returned cursor never skips episodes.
+	result := InboxResult{Data: []EpisodeSummary{}}
+	var nextKey interface{}
+	for page := 0; page < maxInboxPages; page++ {
+		inboxData, err := xyzclient.ListInbox(ctx, apiRequest)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("调用API获取收件箱失败", err), nil
+		}
+
+		reachedSince := false
+		for i := range inboxData.Data {
+			episode := &inboxData.Data[i]
+			if !since.IsZero() && !isOnOrAfter(episode.PubDate, since) {
+				reachedSince = true
+				continue
+			}
+			if unplayedOnly && episode.IsPlayed {
+				continue
+			}
+			result.Data = append(result.Data, newEpisodeSummary(episode))
+		}
+
+		// The inbox is newest first, so nothing on later pages can be newer than 'since'.
+		if reachedSince || len(inboxData.Data) == 0 || inboxData.LoadMoreKey == nil {
+			nextKey = nil
+			break
+		}
+		nextKey = inboxData.LoadMoreKey
+		if len(result.Data) >= limit {
+			break
+		}
+		apiRequest.LoadMoreKey = inboxData.LoadMoreKey
+	}
+
+	page, err := newPage(request, "", nextKey)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+	result.Page = page
+
+	slog.Debug("成功获取收件箱", "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	maxClapCount     = 10
+	maxPickTextRunes = 140
+)
+
+// PickView is one entry of the list_user_picks result.
+type PickView struct {
+	ID        string         `json:"id"`
+	PickText  string         `json:"pickText"`
+	LikeCount int            `json:"likeCount"`
+	CreatedAt string         `json:"createdAt"`
+	Episode   EpisodeSummary `json:"episode"`
+}
+
+// PickListResult is the result of the list_user_picks tool.
+type PickListResult struct {
+	UID  string     `json:"uid"`
+	Data []PickView `json:"data"`
+	Page
+}
+
+// ClapEpisodeHandler is the MCP handler function for the clap_episode tool.
+func ClapEpisodeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing clap_episode tool", "arguments", request.Params.Arguments)
+
+	episodeID, ok := request.GetArguments()["episode_id"].(string)
+	if !ok || episodeID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'episode_id' 不能为空且必须是字符串类型。"), nil
+	}
+	count := intArg(request.GetArguments(), "count", 1, maxClapCount)
+	if result := requireConfirmation(request, "为单集点赞"); result != nil {
+		return result, nil
+	}
+
+	if err := xyzclient.ClapEpisode(ctx, episodeID, count); err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API为单集点赞失败", err), nil
+	}
+
+	slog.Info("Episode clapped", "episode_id", episodeID, "count", count)
+	return newActionResult(fmt.Sprintf("已为单集 %s 点赞 %d 次。", episodeID, count)), nil
+}
+
+// PickEpisodeHandler is the MCP handler function for the pick_episode tool.
+func PickEpisodeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing pick_episode tool", "arguments", request.Params.Arguments)
+
+	episodeID, ok := request.GetArguments()["episode_id"].(string)
+	if !ok || episodeID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'episode_id' 不能为空且必须是字符串类型。"), nil
+	}
+	pickText, _ := request.GetArguments()["text"].(string)
+	pickText = strings.TrimSpace(pickText)
+	if pickText == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'text' 不能为空。"), nil
+	}
+	if utf8.RuneCountInString(pickText) > maxPickTextRunes {
+		return mcp.NewToolResultError(fmt.Sprintf("错误: 推荐语不能超过 %d 个字。", maxPickTextRunes)), nil
+	}
+	if result := requireConfirmation(request, "精选单集"); result != nil {
+		return result, nil
+	}
+
+	if err := xyzclient.CreatePick(ctx, episodeID, pickText); err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API精选单集失败", err), nil
+	}
+
+	slog.Info("Episode picked", "episode_id", episodeID)
+	return newActionResult("已精选单集 " + episodeID + "，推荐语会展示在用户主页上。"), nil
+}
+
+// UnpickEpisodeHandler is the MCP handler function for the unpick_episode tool.
+func UnpickEpisodeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing unpick_episode tool", "arguments", request.Params.Arguments)
+
+	episodeID, ok := request.GetArguments()["episode_id"].(string)
+	if !ok || episodeID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'episode_id' 不能为空且必须是字符串类型。"), nil
+	}
+	if result := requireConfirmation(request, "取消精选单集"); result != nil {
+		return result, nil
+	}
+
+	if err := xyzclient.RemovePick(ctx, episodeID); err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API取消精选失败", err), nil
+	}
+
+	slog.Info("Episode pick removed", "episode_id", episodeID)
+	return newActionResult("已取消精选单集 " + episodeID + "。"), nil
+}
+
+// ListUserPicksHandler is the MCP handler function for the list_user_picks tool.
+func ListUserPicksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_user_picks tool", "arguments", request.Params.Arguments)
+
+	userID, ok := request.GetArguments()["user_id"].(string)
+	if !ok || userID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'user_id' 不能为空且必须是字符串类型。"), nil
+	}
+	userID, err := resolveUserID(ctx, userID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("解析 'user_id' 失败", err), nil
+	}
+
+	apiRequest := xyzclient.PickListRequest{
+		UID:   userID,
+		Limit: intArg(request.GetArguments(), "limit", 20, 50),
+	}
+	scope := cursorScope(userID)
+	if err := cursorArg(request, scope, &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	picksData, err := xyzclient.ListUserPicks(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取用户精选失败", err), nil
+	}
+
+	result := PickListResult{
+		UID:  userID,
+		Data: make([]PickView, 0, len(picksData.Data)),
+	}
+	for i := range picksData.Data {
+		pick := &picksData.Data[i]
+		result.Data = append(result.Data, PickView{
+			ID:        pick.ID,
+			PickText:  pick.PickText,
+			LikeCount: pick.LikeCount,
+			CreatedAt: pick.CreatedAt,
+			Episode:   newEpisodeSummary(&pick.Episode),
+		})
+	}
+
+	if result.Page, err = newPage(request, scope, picksData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取用户精选", "userID", userID, "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
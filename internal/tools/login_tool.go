@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+
+	"xiaoyuzhoufm-mcp/internal/auth"
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const defaultLoginAreaCode = "+86"
+
+var (
+	areaCodePattern         = regexp.MustCompile(`^\+\d{1,3}$`)
+	phoneNumberPattern      = regexp.MustCompile(`^\d{7,15}$`)
+	verificationCodePattern = regexp.MustCompile(`^\d{4}$`)
+)
+
+// LoginResult is the result of the complete_login tool.
+type LoginResult struct {
+	Principal string `json:"principal"`
+	UID       string `json:"uid"`
+	Nickname  string `json:"nickname"`
+}
+
+// StartLoginHandler is the MCP handler function for the start_login tool, which sends an SMS
+// verification code to the phone number of the Xiaoyuzhou account to bind.
+func StartLoginHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing start_login tool")
+
+	areaCode, phoneNumber, result := loginPhoneArgs(request)
+	if result != nil {
+		return result, nil
+	}
+	if err := xyzclient.RequestVerificationCode(areaCode, phoneNumber); err != nil {
+		return mcp.NewToolResultErrorFromErr("发送验证码失败", err), nil
+	}
+	return newActionResult("验证码已发送，请向用户索取 4 位验证码后调用 complete_login。"), nil
+}
+
+// CompleteLoginHandler returns the MCP handler function for the complete_login tool. It logs
+// in with the verification code and binds the account to the calling principal.
+func CompleteLoginHandler(store *xyzclient.TenantStore) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("Executing complete_login tool")
+
+		principal := auth.PrincipalFromContext(ctx)
+		if principal == nil {
+			return mcp.NewToolResultError("错误: 当前请求未认证，无法绑定小宇宙账号。"), nil
+		}
+		if result := requireConfirmation(request, "为当前身份绑定小宇宙账号"); result != nil {
+			return result, nil
+		}
+		areaCode, phoneNumber, result := loginPhoneArgs(request)
+		if result != nil {
+			return result, nil
+		}
+		code, _ := request.GetArguments()["verification_code"].(string)
+		if !verificationCodePattern.MatchString(code) {
+			return mcp.NewToolResultError("错误: 输入参数 'verification_code' 必须是 4 位数字。"), nil
+		}
+
+		accessToken, refreshToken, uid, nickname, err := xyzclient.LoginWithCode(areaCode, phoneNumber, code)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("登录失败", err), nil
+		}
+		if err := store.Login(principal.Name, accessToken, refreshToken, uid, nickname); err != nil {
+			return mcp.NewToolResultErrorFromErr("保存登录状态失败", err), nil
+		}
+
+		return newToolResult(request, LoginResult{Principal: principal.Name, UID: uid, Nickname: nickname}), nil
+	}
+}
+
+// LogoutHandler returns the MCP handler function for the logout tool, which revokes and removes
+// the Xiaoyuzhou account bound to the calling principal.
+func LogoutHandler(store *xyzclient.TenantStore) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("Executing logout tool", "arguments", request.Params.Arguments)
+
+		principal := auth.PrincipalFromContext(ctx)
+		if principal == nil {
+			return mcp.NewToolResultError("错误: 当前请求未认证，无法退出登录。"), nil
+		}
+		if result := requireConfirmation(request, "退出小宇宙登录"); result != nil {
+			return result, nil
+		}
+
+		if err := store.Logout(principal.Name); err != nil {
+			if errors.Is(err, xyzclient.ErrNotLoggedIn) {
+				return newActionResult("当前身份未绑定小宇宙账号，无需退出。"), nil
+			}
+			return mcp.NewToolResultErrorFromErr("退出登录未能完全完成", err), nil
+		}
+		return newActionResult("已退出登录，本地令牌与账号缓存已清除。"), nil
+	}
+}
+
+// loginPhoneArgs reads and validates the area code and phone number arguments.
+func loginPhoneArgs(request mcp.CallToolRequest) (string, string, *mcp.CallToolResult) {
+	areaCode, _ := request.GetArguments()["area_code"].(string)
+	if areaCode == "" {
+		areaCode = defaultLoginAreaCode
+	}
+	if !areaCodePattern.MatchString(areaCode) {
+		return "", "", mcp.NewToolResultError("错误: 输入参数 'area_code' 格式不正确，应为 '+' 加 1 到 3 位数字，例如 +86。")
+	}
+	phoneNumber, _ := request.GetArguments()["phone_number"].(string)
+	if !phoneNumberPattern.MatchString(phoneNumber) {
+		return "", "", mcp.NewToolResultError("错误: 输入参数 'phone_number' 必须是 7 到 15 位数字。")
+	}
+	return areaCode, phoneNumber, nil
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Patterns used by htmlToMarkdown. Shownotes are simple editor HTML, so a handful of
+// rewrites is enough and avoids pulling in a full HTML parser.
+var (
+	htmlLinkPattern      = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+	htmlImagePattern     = regexp.MustCompile(`(?is)<img\s[^>]*src\s*=\s*["']([^"']*)["'][^>]*>`)
+	htmlImageAltPattern  = regexp.MustCompile(`(?is)alt\s*=\s*["']([^"']*)["']`)
+	htmlHeadingPattern   = regexp.MustCompile(`(?is)<h([1-6])[^>]*>(.*?)</h[1-6]>`)
+	htmlBoldPattern      = regexp.MustCompile(`(?is)<(?:strong|b)(?:\s[^>]*)?>(.*?)</(?:strong|b)>`)
+	htmlItalicPattern    = regexp.MustCompile(`(?is)<(?:em|i)(?:\s[^>]*)?>(.*?)</(?:em|i)>`)
+	htmlListItemPattern  = regexp.MustCompile(`(?is)<li[^>]*>`)
+	htmlLineBreakPattern = regexp.MustCompile(`(?is)<br\s*/?>`)
+	htmlBlockEndPattern  = regexp.MustCompile(`(?is)</(?:p|div|ul|ol|blockquote|figure|section)>`)
+	htmlTagPattern       = regexp.MustCompile(`(?s)<[^>]+>`)
+	blankLinesPattern    = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToMarkdown converts shownotes HTML into readable Markdown.
+func htmlToMarkdown(content string) string {
+	if !strings.Contains(content, "<") {
+		return strings.TrimSpace(html.UnescapeString(content))
+	}
+
+	content = htmlImagePattern.ReplaceAllStringFunc(content, func(tag string) string {
+		src := htmlImagePattern.FindStringSubmatch(tag)[1]
+		alt := ""
+		if m := htmlImageAltPattern.FindStringSubmatch(tag); m != nil {
+			alt = m[1]
+		}
+		return "![" + alt + "](" + src + ")"
+	})
+	content = htmlLinkPattern.ReplaceAllStringFunc(content, func(tag string) string {
+		m := htmlLinkPattern.FindStringSubmatch(tag)
+		text := strings.TrimSpace(htmlTagPattern.ReplaceAllString(m[2], ""))
+		if text == "" || text == m[1] {
+			return m[1]
+		}
+		return "[" + text + "](" + m[1] + ")"
+	})
+	content = htmlHeadingPattern.ReplaceAllStringFunc(content, func(tag string) string {
+		m := htmlHeadingPattern.FindStringSubmatch(tag)
+		return "\n\n" + strings.Repeat("#", int(m[1][0]-'0')) + " " + strings.TrimSpace(m[2]) + "\n\n"
+	})
+	content = htmlBoldPattern.ReplaceAllString(content, "**$1**")
+	content = htmlItalicPattern.ReplaceAllString(content, "*$1*")
+	content = htmlListItemPattern.ReplaceAllString(content, "\n- ")
+	content = htmlLineBreakPattern.ReplaceAllString(content, "\n")
+	content = htmlBlockEndPattern.ReplaceAllString(content, "\n\n")
+	content = htmlTagPattern.ReplaceAllString(content, "")
+	content = html.UnescapeString(content)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\u00a0")
+	}
+	content = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(content)
+}
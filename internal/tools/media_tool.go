@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Sources of the URL returned by get_episode_media_url.
+const (
+	mediaSourceSigned    = "signed"
+	mediaSourceMedia     = "media"
+	mediaSourceEnclosure = "enclosure"
+)
+
+// EpisodeMediaURL is the result of the get_episode_media_url tool.
+type EpisodeMediaURL struct {
+	EID       string `json:"eid"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	MimeType  string `json:"mimeType,omitempty"`
+	Size      int64  `json:"size,omitempty"` // Bytes
+	Source    string `json:"source"`         // "signed", "media" or "enclosure"
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
+// GetEpisodeMediaURLHandler is the MCP handler function for the get_episode_media_url tool.
+func GetEpisodeMediaURLHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing get_episode_media_url tool", "arguments", request.Params.Arguments)
+
+	episodeID, ok := request.GetArguments()["episode_id"].(string)
+	if !ok || episodeID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'episode_id' 不能为空且必须是字符串类型。"), nil
+	}
+
+	episode, err := xyzclient.GetEpisodeDetailsByID(ctx, episodeID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取单集详情失败", err), nil
+	}
+	if episode.ComputeAccessStatus() == xyzclient.AccessLocked {
+		return mcp.NewToolResultError("该单集为付费内容，当前用户尚未购买，无法获取可播放地址。"), nil
+	}
+
+	result := EpisodeMediaURL{
+		EID:      episode.EID,
+		Title:    episode.Title,
+		MimeType: episode.Media.MimeType,
+		Size:     episode.Media.Size,
+	}
+
+	// Private and paid media need a signed URL; public media can be played from the source URL directly.
+	if (episode.IsPrivateMedia || episode.IsPaid()) && episode.MediaKey != "" {
+		media, err := xyzclient.GetPrivateMediaURL(ctx, episode.EID, episode.MediaKey)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("调用API获取签名媒体地址失败", err), nil
+		}
+		result.URL = media.URL
+		result.Source = mediaSourceSigned
+		result.ExpiresAt = media.ExpireAt
+		if media.MimeType != "" {
+			result.MimeType = media.MimeType
+		}
+		if media.Size > 0 {
+			result.Size = media.Size
+		}
+	} else if episode.Media.Source.URL != "" {
+		result.URL = episode.Media.Source.URL
+		result.Source = mediaSourceMedia
+	} else if episode.Enclosure.URL != "" {
+		result.URL = episode.Enclosure.URL
+		result.Source = mediaSourceEnclosure
+	} else {
+		return mcp.NewToolResultError("该单集没有可用的媒体地址。"), nil
+	}
+
+	slog.Debug("成功获取单集媒体地址", "episode_id", episodeID, "source", result.Source)
+	return newToolResult(request, result), nil
+}
@@ -0,0 +1,341 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Detail levels accepted by the 'detail' argument.
+const (
+	DetailMinimal  = "minimal"  // Identifiers and the fields needed to pick an item
+	DetailStandard = "standard" // Everything except images, permissions and client-only flags (default)
+	DetailFull     = "full"     // The API data unchanged
+)
+
+// DetailLevels lists the accepted values of the 'detail' argument.
+var DetailLevels = []string{DetailMinimal, DetailStandard, DetailFull}
+
+// noisyKeys are dropped from every object below the full detail level. They are either image
+// URLs, app display hints or per-viewer flags that a model never needs.
+var noisyKeys = keySet(
+	"image", "avatar", "color", "permissions", "readTrackInfo", "wechatShare", "sponsors",
+	"transcript", "isCustomized", "showZhuiguangIcon", "syncMode", "subscriptionPush",
+	"subscriptionPushPriority", "subscriptionStar", "isBlockedByViewer", "isNicknameSet", "isCancelled", "ipLoc",
+)
+
+// entityPreset is what each detail level drops from one kind of object. Kinds are recognized by
+// their identifying key, so the presets apply to raw API types and compact views alike.
+type entityPreset struct {
+	idKey        string
+	standardOmit map[string]bool
+	minimalOmit  map[string]bool
+}
+
+// entityPresets are checked in order: episodes embed a pid, so they must match first.
+var entityPresets = []entityPreset{
+	{ // Episodes
+		idKey:        "eid",
+		standardOmit: keySet("media", "labels"), // Episode labels are display badges; topic labels are kept
+		minimalOmit: keySet("description", "shownotes", "enclosure", "media", "mediaKey", "isPrivateMedia",
+			"playCount", "clapCount", "commentCount", "favoriteCount", "status", "payType", "isPurchased", "topicId", "type", "isFavorited", "isPicked"),
+	},
+	{ // Podcasts
+		idKey: "pid",
+		minimalOmit: keySet("description", "brief", "contacts", "podcasters", "topicLabels", "payType",
+			"payEpisodeCount", "hasTopic", "hasPopularEpisodes", "playTime", "status", "subscriptionStatus", "type"),
+	},
+	{ // Users
+		idKey:       "uid",
+		minimalOmit: keySet("bio", "gender"),
+	},
+}
+
+// newToolResult renders v as the result of a tool call, applying the 'detail', 'fields' and
+// 'format' arguments of the request. The shaped data is also returned as structured content.
+// It is the single exit point for tools returning data.
+func newToolResult(request mcp.CallToolRequest, v interface{}) *mcp.CallToolResult {
+	detail := DetailStandard
+	if arg, ok := request.GetArguments()["detail"].(string); ok && arg != "" {
+		detail = arg
+	}
+	if !isDetailLevel(detail) {
+		return mcp.NewToolResultError("错误: 输入参数 'detail' 必须是 " + strings.Join(DetailLevels, "、") + " 之一。")
+	}
+	format := FormatJSON
+	if arg, ok := request.GetArguments()["format"].(string); ok && arg != "" {
+		format = arg
+	}
+	render, ok := resultRenderers[format]
+	if !ok {
+		return mcp.NewToolResultError("错误: 输入参数 'format' 必须是 " + strings.Join(OutputFormats, "、") + " 之一。")
+	}
+	fields := fieldsArg(request.GetArguments())
+
+	fullJSON, err := json.Marshal(v)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("处理结果失败", err)
+	}
+
+	value, err := decodeJSON(fullJSON)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("处理结果失败", err)
+	}
+	text := string(fullJSON)
+	// Array results are wrapped before shaping, so that the text, the structured content and
+	// the 'fields' paths all follow the declared output schema.
+	if _, isObject := value.(map[string]interface{}); !isObject {
+		value = structuredContent(value)
+		text = `{"data":` + text + `}`
+	}
+	if detail != DetailFull || len(fields) > 0 || format != FormatJSON {
+		value = shapeValue(value, detail, fields)
+		text, err = render(value)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("处理结果失败", err)
+		}
+	}
+
+	slog.Info("Tool response.", "tool", request.Params.Name, "detail", detail, "fields", len(fields), "format", format,
+		"bytes", len(text), "estimatedTokens", estimateTokens(text), "fullEstimatedTokens", estimateTokens(string(fullJSON)))
+	return mcp.NewToolResultStructured(value, text)
+}
+
+// structuredContent returns the shaped value as the structuredContent of a result. It must be
+// an object, so other values are wrapped as {"data": value}, matching the declared schemas.
+func structuredContent(value interface{}) interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		return object
+	}
+	return map[string]interface{}{"data": value}
+}
+
+// ActionResult is the result of a tool that changes account data or the login state.
+type ActionResult struct {
+	Message string `json:"message"`
+}
+
+// newActionResult reports a completed action as both text and structured content.
+func newActionResult(message string) *mcp.CallToolResult {
+	return mcp.NewToolResultStructured(ActionResult{Message: message}, message)
+}
+
+// decodeJSON decodes data into generic values, keeping numbers exact.
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", err)
+	}
+	return value, nil
+}
+
+// shapeValue applies a field selection, or a detail preset when no fields are selected. A field
+// selection works on the full data, so that any field can be asked for explicitly.
+func shapeValue(value interface{}, detail string, fields []string) interface{} {
+	if len(fields) == 0 {
+		return applyDetail(value, detail)
+	}
+	var selected interface{} = map[string]interface{}{}
+	for _, field := range fields {
+		if picked, ok := selectPath(value, splitFieldPath(field)); ok {
+			selected = mergeSelections(selected, picked)
+		} else {
+			slog.Debug("Field selection matched nothing.", "field", field)
+		}
+	}
+	return selected
+}
+
+// applyDetail drops the keys the detail level omits, recursively. The minimal level also drops
+// empty values.
+func applyDetail(value interface{}, detail string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		preset := presetFor(v)
+		for key, child := range v {
+			if omitKey(key, preset, detail) {
+				delete(v, key)
+				continue
+			}
+			v[key] = applyDetail(child, detail)
+			if detail == DetailMinimal && isEmptyValue(v[key]) {
+				delete(v, key)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = applyDetail(v[i], detail)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func presetFor(object map[string]interface{}) *entityPreset {
+	for i := range entityPresets {
+		if _, ok := object[entityPresets[i].idKey]; ok {
+			return &entityPresets[i]
+		}
+	}
+	return nil
+}
+
+func omitKey(key string, preset *entityPreset, detail string) bool {
+	if noisyKeys[key] {
+		return true
+	}
+	if preset == nil {
+		return false
+	}
+	if preset.standardOmit[key] {
+		return true
+	}
+	return detail == DetailMinimal && preset.minimalOmit[key]
+}
+
+// splitFieldPath splits a path like "data[].podcast.title" or "$.data.*.eid" into keys.
+// Arrays are traversed implicitly, so "[]" is optional; "*" matches every key of an object.
+func splitFieldPath(field string) []string {
+	field = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(field), "$"), ".")
+	var path []string
+	for _, segment := range strings.Split(field, ".") {
+		segment = strings.TrimSuffix(segment, "[]")
+		if segment != "" {
+			path = append(path, segment)
+		}
+	}
+	return path
+}
+
+// selectPath returns the part of value reached by path, keeping the enclosing structure.
+func selectPath(value interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		selected := make([]interface{}, len(v))
+		matched := false
+		for i, element := range v {
+			if picked, ok := selectPath(element, path); ok {
+				selected[i] = picked
+				matched = true
+			} else {
+				selected[i] = map[string]interface{}{}
+			}
+		}
+		return selected, matched
+	case map[string]interface{}:
+		selected := map[string]interface{}{}
+		for key, child := range v {
+			if path[0] != "*" && path[0] != key {
+				continue
+			}
+			if picked, ok := selectPath(child, path[1:]); ok {
+				selected[key] = picked
+			}
+		}
+		return selected, len(selected) > 0
+	default:
+		return nil, false
+	}
+}
+
+// mergeSelections combines two selections of the same value.
+func mergeSelections(a, b interface{}) interface{} {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return b
+		}
+		for key, child := range bv {
+			if existing, ok := av[key]; ok {
+				av[key] = mergeSelections(existing, child)
+			} else {
+				av[key] = child
+			}
+		}
+		return av
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return b
+		}
+		for i := range av {
+			av[i] = mergeSelections(av[i], bv[i])
+		}
+		return av
+	default:
+		return b
+	}
+}
+
+// fieldsArg reads the 'fields' argument, given either as an array or a comma-separated string.
+func fieldsArg(arguments map[string]interface{}) []string {
+	if s, ok := arguments["fields"].(string); ok {
+		var fields []string
+		for _, field := range strings.Split(s, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+		return fields
+	}
+	return stringSliceArg(arguments, "fields")
+}
+
+func isDetailLevel(detail string) bool {
+	for _, level := range DetailLevels {
+		if detail == level {
+			return true
+		}
+	}
+	return false
+}
+
+// estimateTokens roughly estimates how many tokens a model needs for s: about one token per
+// CJK character and one per four other characters.
+func estimateTokens(s string) int {
+	cjk, other := 0, 0
+	for _, r := range s {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+		} else {
+			other++
+		}
+	}
+	return cjk + (other+3)/4
+}
+
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// mustDecode decodes a JSON literal the way newToolResult decodes tool results.
+func mustDecode(t *testing.T, s string) interface{} {
+	t.Helper()
+	value, err := decodeJSON([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func assertJSON(t *testing.T, got interface{}, want string) {
+	t.Helper()
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotValue, wantValue interface{}
+	json.Unmarshal(data, &gotValue)
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("bad expectation %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestSplitFieldPath(t *testing.T) {
+	tests := []struct {
+		field string
+		want  []string
+	}{
+		{"title", []string{"title"}},
+		{"data[].podcast.title", []string{"data", "podcast", "title"}},
+		{"$.data.*.eid", []string{"data", "*", "eid"}},
+		{" data..eid ", []string{"data", "eid"}},
+		{"$", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := splitFieldPath(tt.field); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitFieldPath(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestSelectPath(t *testing.T) {
+	const episodes = `{"data":[{"eid":"e1","title":"A","podcast":{"pid":"p1","title":"P"}},{"eid":"e2"}],"total":2}`
+	tests := []struct {
+		name    string
+		value   string
+		path    string
+		want    string
+		matched bool
+	}{
+		{"top-level key", episodes, "total", `{"total":2}`, true},
+		{"through an array", episodes, "data.eid", `{"data":[{"eid":"e1"},{"eid":"e2"}]}`, true},
+		{"missing in some elements", episodes, "data.title", `{"data":[{"title":"A"},{}]}`, true},
+		{"nested object", episodes, "data[].podcast.title", `{"data":[{"podcast":{"title":"P"}},{}]}`, true},
+		{"wildcard", `{"a":{"id":1},"b":{"id":2,"x":3}}`, "*.id", `{"a":{"id":1},"b":{"id":2}}`, true},
+		{"empty path selects everything", `{"a":1}`, "", `{"a":1}`, true},
+		{"unknown key", episodes, "nope", `{}`, false},
+		{"path below a scalar", episodes, "total.value", `{}`, false},
+		{"no element matches", episodes, "data.podcast.nope", `{"data":[{},{}]}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, matched := selectPath(mustDecode(t, tt.value), splitFieldPath(tt.path))
+			if matched != tt.matched {
+				t.Errorf("selectPath matched = %v, want %v", matched, tt.matched)
+			}
+			if matched {
+				assertJSON(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSelections(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"disjoint keys", `{"a":1}`, `{"b":2}`, `{"a":1,"b":2}`},
+		{"nested objects", `{"p":{"x":1}}`, `{"p":{"y":2}}`, `{"p":{"x":1,"y":2}}`},
+		{"arrays element-wise", `{"data":[{"eid":"e1"},{}]}`, `{"data":[{"title":"A"},{"title":"B"}]}`, `{"data":[{"eid":"e1","title":"A"},{"title":"B"}]}`},
+		{"arrays of different length", `[1]`, `[1,2]`, `[1,2]`},
+		{"object replaced by scalar", `{"a":1}`, `3`, `3`},
+		{"scalar replaced", `1`, `{"a":2}`, `{"a":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSON(t, mergeSelections(mustDecode(t, tt.a), mustDecode(t, tt.b)), tt.want)
+		})
+	}
+}
+
+func TestApplyDetail(t *testing.T) {
+	const episode = `{"eid":"e1","title":"A","description":"d","media":{"size":1},"image":{"url":"u"},"playCount":3,"shownotes":"",` +
+		`"podcast":{"pid":"p1","title":"P","brief":"b","avatar":{}}}`
+	tests := []struct {
+		name   string
+		value  string
+		detail string
+		want   string
+	}{
+		{"standard drops noisy keys and media", episode, DetailStandard,
+			`{"eid":"e1","title":"A","description":"d","playCount":3,"shownotes":"","podcast":{"pid":"p1","title":"P","brief":"b"}}`},
+		{"minimal drops preset keys and empty values", episode, DetailMinimal,
+			`{"eid":"e1","title":"A","podcast":{"pid":"p1","title":"P"}}`},
+		{"episode labels are dropped", `{"eid":"e1","labels":[{"text":"新"}]}`, DetailStandard, `{"eid":"e1"}`},
+		{"topic labels are kept", `{"id":"t1","pid":"p1","labels":["科技"]}`, DetailStandard, `{"id":"t1","pid":"p1","labels":["科技"]}`},
+		{"user preset", `{"uid":"u1","nickname":"N","bio":"b","gender":"M"}`, DetailMinimal, `{"uid":"u1","nickname":"N"}`},
+		{"arrays", `[{"uid":"u1","avatar":{}},{"pid":"p1","title":"P","description":"d"}]`, DetailMinimal,
+			`[{"uid":"u1"},{"pid":"p1","title":"P"}]`},
+		{"objects without preset keep their keys", `{"message":"ok","count":0,"empty":""}`, DetailStandard,
+			`{"message":"ok","count":0,"empty":""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSON(t, applyDetail(mustDecode(t, tt.value), tt.detail), tt.want)
+		})
+	}
+}
+
+func TestFieldsArg(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		want      []string
+	}{
+		{"comma-separated", map[string]interface{}{"fields": "data.eid, data.title ,,"}, []string{"data.eid", "data.title"}},
+		{"array", map[string]interface{}{"fields": []interface{}{"data.eid", "total"}}, []string{"data.eid", "total"}},
+		{"absent", map[string]interface{}{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldsArg(tt.arguments); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fieldsArg = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewToolResultRejectsBadArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+	}{
+		{"unknown detail", map[string]interface{}{"detail": "verbose"}},
+		{"unknown format", map[string]interface{}{"format": "html"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request mcp.CallToolRequest
+			request.Params.Name = "get_podcast_details"
+			request.Params.Arguments = tt.arguments
+			if result := newToolResult(request, map[string]string{"pid": "p1"}); !result.IsError {
+				t.Errorf("newToolResult(%v) is not an error", tt.arguments)
+			}
+		})
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abcd", 1},
+		{"abcde", 2},
+		{"播客", 2},
+		{"AI播客", 3},
+	}
+	for _, tt := range tests {
+		if got := estimateTokens(tt.s); got != tt.want {
+			t.Errorf("estimateTokens(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestNewToolResultWrapsArrays(t *testing.T) {
+	episodes := []map[string]string{{"eid": "e1", "title": "A"}, {"eid": "e2", "title": "B"}}
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		want      string
+	}{
+		{"full JSON", map[string]interface{}{"detail": DetailFull}, `{"data":[{"eid":"e1","title":"A"},{"eid":"e2","title":"B"}]}`},
+		{"field selection", map[string]interface{}{"fields": "data.title"}, `{"data":[{"title":"A"},{"title":"B"}]}`},
+		{"minimal detail", map[string]interface{}{"detail": DetailMinimal}, `{"data":[{"eid":"e1","title":"A"},{"eid":"e2","title":"B"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request mcp.CallToolRequest
+			request.Params.Name = "list_popular_episodes"
+			request.Params.Arguments = tt.arguments
+			result := newToolResult(request, episodes)
+			if result.IsError {
+				t.Fatalf("newToolResult failed: %v", result.Content)
+			}
+			assertJSON(t, result.StructuredContent, tt.want)
+			text, ok := result.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("content = %T, want text", result.Content[0])
+			}
+			assertJSON(t, json.RawMessage(text.Text), tt.want)
+		})
+	}
+}
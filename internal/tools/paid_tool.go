@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Values of the 'access' filter of list_podcast_episodes.
+const (
+	accessFilterAll       = "all"
+	accessFilterFree      = "free"
+	accessFilterPaid      = "paid"
+	accessFilterPurchased = "purchased"
+)
+
+// PurchasedItemView is one entry of the list_purchased_content result.
+type PurchasedItemView struct {
+	Type        string           `json:"type"`
+	PurchasedAt string           `json:"purchasedAt"`
+	Podcast     *PodcastOverview `json:"podcast,omitempty"`
+	Episode     *EpisodeSummary  `json:"episode,omitempty"`
+}
+
+// PurchasedListResult is the result of the list_purchased_content tool.
+type PurchasedListResult struct {
+	Data []PurchasedItemView `json:"data"`
+	Page
+}
+
+// matchesAccessFilter reports whether an episode passes the 'access' filter.
+func matchesAccessFilter(episode *xyzclient.Episode, filter string) bool {
+	switch filter {
+	case accessFilterFree:
+		return !episode.IsPaid()
+	case accessFilterPaid:
+		return episode.IsPaid()
+	case accessFilterPurchased:
+		return episode.ComputeAccessStatus() == xyzclient.AccessPurchased
+	default:
+		return true
+	}
+}
+
+// redactLockedMedia clears the media URLs of a locked episode, which would not play, so that
+// the model does not hand them to the user. accessStatus tells why they are empty.
+func redactLockedMedia(episode *xyzclient.Episode) {
+	if episode.ComputeAccessStatus() != xyzclient.AccessLocked {
+		return
+	}
+	episode.Enclosure.URL = ""
+	episode.Media.Source.URL = ""
+}
+
+// ListPurchasedContentHandler is the MCP handler function for the list_purchased_content tool.
+func ListPurchasedContentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_purchased_content tool", "arguments", request.Params.Arguments)
+
+	apiRequest := xyzclient.PurchasedListRequest{
+		Limit: intArg(request.GetArguments(), "limit", 20, 50),
+	}
+	if err := cursorArg(request, "", &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	purchasedData, err := xyzclient.ListPurchasedContent(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取已购内容失败", err), nil
+	}
+
+	result := PurchasedListResult{
+		Data: make([]PurchasedItemView, 0, len(purchasedData.Data)),
+	}
+	for _, item := range purchasedData.Data {
+		view := PurchasedItemView{Type: item.Type, PurchasedAt: item.PurchasedAt}
+		if item.Podcast != nil {
+			podcast := newPodcastOverview(item.Podcast)
+			view.Podcast = &podcast
+		}
+		if item.Episode != nil {
+			summary := newEpisodeSummary(item.Episode)
+			view.Episode = &summary
+		}
+		result.Data = append(result.Data, view)
+	}
+
+	if result.Page, err = newPage(request, "", purchasedData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取已购内容", "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
@@ -2,7 +2,6 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 
 	"xiaoyuzhoufm-mcp/internal/xyzclient"
@@ -14,30 +13,32 @@ import (
 func GetPodcastDetailsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	slog.Debug("Executing get_podcast_details tool", "arguments", request.Params.Arguments)
 
-	podcastID, ok := request.Params.Arguments["podcast_id"].(string)
+	podcastID, ok := request.GetArguments()["podcast_id"].(string)
 	if !ok || podcastID == "" {
 		return mcp.NewToolResultError("输入参数 'podcast_id' 不能为空且必须是字符串类型。"), nil
 	}
 
-	podcastDetailsData, err := xyzclient.GetPodcastDetailsByID(podcastID)
+	podcastDetailsData, err := xyzclient.GetPodcastDetailsByID(ctx, podcastID)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("调用API获取 PodcastDetails 失败", err), nil
 	}
 
-	podcastDetailsJSON, err := json.Marshal(podcastDetailsData)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("处理结果失败", err), nil
-	}
-
 	slog.Debug("成功获取 PodcastDetails", "podcast_id", podcastID)
-	return mcp.NewToolResultText(string(podcastDetailsJSON)), nil
+	return newToolResult(request, podcastDetailsData), nil
+}
+
+// EpisodeListResult is the result of the list_podcast_episodes tool.
+type EpisodeListResult struct {
+	Data  []xyzclient.Episode `json:"data"`
+	Order string              `json:"order"`
+	Page
 }
 
 // ListPodcastEpisodesHandler is the MCP handler function for the ListPodcastEpisodesTool.
 func ListPodcastEpisodesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	slog.Debug("Executing list_podcast_episodes tool", "arguments", request.Params.Arguments)
 
-	podcastID, ok := request.Params.Arguments["podcast_id"].(string)
+	podcastID, ok := request.GetArguments()["podcast_id"].(string)
 	if !ok || podcastID == "" {
 		return mcp.NewToolResultError("错误: 输入参数 'podcast_id' 不能为空且必须是字符串类型。"), nil
 	}
@@ -47,7 +48,7 @@ func ListPodcastEpisodesHandler(ctx context.Context, request mcp.CallToolRequest
 		Limit: 20,
 	}
 
-	if order, ok := request.Params.Arguments["order"].(string); ok && order != "" {
+	if order, ok := request.GetArguments()["order"].(string); ok && order != "" {
 		if order != "asc" && order != "desc" {
 			return mcp.NewToolResultError("错误: 输入参数 'order' 必须是 'asc' 或 'desc'。"), nil
 		}
@@ -56,59 +57,120 @@ func ListPodcastEpisodesHandler(ctx context.Context, request mcp.CallToolRequest
 		apiRequest.Order = "desc" // Default value
 	}
 
-	if lmkMap, ok := request.Params.Arguments["load_more_key"].(map[string]interface{}); ok && lmkMap != nil {
-		lmk := &xyzclient.LoadMoreKey{}
-		if direction, ok := lmkMap["direction"].(string); ok {
-			lmk.Direction = direction
-		}
-		if pubDate, ok := lmkMap["pubDate"].(string); ok {
-			lmk.PubDate = pubDate
-		}
-		if id, ok := lmkMap["id"].(string); ok {
-			lmk.ID = id
-		}
-		// Only assign if at least one field was present, or if the object itself was non-nil
-		// The xyzclient.ListPodcastEpisodes will handle nil LoadMoreKey if no fields are set.
-		if lmk.Direction != "" || lmk.PubDate != "" || lmk.ID != "" {
-			apiRequest.LoadMoreKey = lmk
+	scope := cursorScope(podcastID, apiRequest.Order)
+	if err := cursorArg(request, scope, &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	accessFilter := accessFilterAll
+	if access, ok := request.GetArguments()["access"].(string); ok && access != "" {
+		switch access {
+		case accessFilterAll, accessFilterFree, accessFilterPaid, accessFilterPurchased:
+			accessFilter = access
+		default:
+			return mcp.NewToolResultError("错误: 输入参数 'access' 必须是 'all'、'free'、'paid' 或 'purchased'。"), nil
 		}
 	}
 
 	slog.Debug("Constructed API request for ListPodcastEpisodes", "apiRequest", apiRequest)
 
-	episodeListData, err := xyzclient.ListPodcastEpisodes(apiRequest)
+	episodeListData, err := xyzclient.ListPodcastEpisodes(ctx, apiRequest)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("调用API获取播客单集列表失败", err), nil
 	}
 
-	episodeListJSON, err := json.Marshal(episodeListData)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("处理结果失败", err), nil
+	// The filter applies to the fetched page only; the cursor still refers to the unfiltered list.
+	result := EpisodeListResult{
+		Data:  make([]xyzclient.Episode, 0, len(episodeListData.Data)),
+		Order: episodeListData.Order,
+	}
+	for i := range episodeListData.Data {
+		episode := &episodeListData.Data[i]
+		if !matchesAccessFilter(episode, accessFilter) {
+			continue
+		}
+		redactLockedMedia(episode)
+		result.Data = append(result.Data, *episode)
 	}
-	slog.Debug("成功获取播客单集列表", "podcast_id", podcastID, "count", len(episodeListData.Data))
 
-	return mcp.NewToolResultText(string(episodeListJSON)), nil
+	var nextKey interface{}
+	if len(episodeListData.Data) > 0 && episodeListData.LoadMoreKey != (xyzclient.LoadMoreKey{}) {
+		nextKey = episodeListData.LoadMoreKey
+	}
+	if result.Page, err = newPage(request, scope, nextKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+	result.Total = &episodeListData.Total
+
+	slog.Debug("成功获取播客单集列表", "podcast_id", podcastID, "count", len(result.Data))
+	return newToolResult(request, result), nil
 }
 
 // GetEpisodeDetailsHandler is the MCP handler function for the GetEpisodeDetailsTool.
 func GetEpisodeDetailsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	slog.Debug("Executing get_episode_details tool", "arguments", request.Params.Arguments)
 
-	episodeID, ok := request.Params.Arguments["episode_id"].(string)
+	episodeID, ok := request.GetArguments()["episode_id"].(string)
 	if !ok || episodeID == "" {
 		return mcp.NewToolResultError("输入参数 'episode_id' 不能为空且必须是字符串类型。"), nil
 	}
 
-	episodeDetailsData, err := xyzclient.GetEpisodeDetailsByID(episodeID)
+	episodeDetailsData, err := xyzclient.GetEpisodeDetailsByID(ctx, episodeID)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("调用API获取单集详情失败", err), nil
 	}
+	redactLockedMedia(episodeDetailsData)
 
-	episodeDetailsJSON, err := json.Marshal(episodeDetailsData)
+	slog.Debug("成功获取单集详情", "episode_id", episodeID, "title", episodeDetailsData.Title)
+	return newToolResult(request, episodeDetailsData), nil
+}
+
+// PopularEpisode is one entry of the list_popular_episodes result.
+type PopularEpisode struct {
+	Rank int `json:"rank"`
+	EpisodeSummary
+	PlayCount    int `json:"playCount"`
+	CommentCount int `json:"commentCount"`
+}
+
+// PopularEpisodesResult is the result of the list_popular_episodes tool. Hint is only set when
+// the platform has no ranking for the podcast.
+type PopularEpisodesResult struct {
+	Data []PopularEpisode `json:"data"`
+	Hint string           `json:"hint,omitempty"`
+}
+
+// ListPopularEpisodesHandler is the MCP handler function for the list_popular_episodes tool.
+func ListPopularEpisodesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_popular_episodes tool", "arguments", request.Params.Arguments)
+
+	podcastID, ok := request.GetArguments()["podcast_id"].(string)
+	if !ok || podcastID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'podcast_id' 不能为空且必须是字符串类型。"), nil
+	}
+	limit := intArg(request.GetArguments(), "limit", 10, 50)
+
+	episodes, err := xyzclient.ListPopularEpisodes(ctx, podcastID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("处理结果失败", err), nil
+		return mcp.NewToolResultErrorFromErr("调用API获取热门单集失败", err), nil
+	}
+
+	result := PopularEpisodesResult{Data: make([]PopularEpisode, 0, min(limit, len(episodes)))}
+	if len(episodes) == 0 {
+		result.Hint = "该播客暂无平台热门单集数据，可以使用 list_podcast_episodes 按时间浏览单集。"
+	}
+	for i := range episodes {
+		if len(result.Data) >= limit {
+			break
+		}
+		result.Data = append(result.Data, PopularEpisode{
+			Rank:           i + 1,
+			EpisodeSummary: newEpisodeSummary(&episodes[i]),
+			PlayCount:      episodes[i].PlayCount,
+			CommentCount:   episodes[i].CommentCount,
+		})
 	}
-	slog.Debug("成功获取单集详情", "episode_id", episodeID, "title", episodeDetailsData.Title)
 
-	return mcp.NewToolResultText(string(episodeDetailsJSON)), nil
+	slog.Debug("成功获取热门单集", "podcast_id", podcastID, "count", len(result.Data))
+	return newToolResult(request, result), nil
 }
@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+//go:embed prompts/*.tmpl
+var builtinPrompts embed.FS
+
+const promptFileExt = ".tmpl"
+
+// Prompt template files start with a comment holding their metadata as JSON:
+//
+//	{{/* {"description": "...", "arguments": [{"name": "eid", "description": "...", "required": true}]} */}}
+const (
+	promptHeaderStart = "{{/*"
+	promptHeaderEnd   = "*/}}"
+)
+
+// promptHeader is the metadata of a prompt template file.
+type promptHeader struct {
+	Description string               `json:"description"`
+	Arguments   []mcp.PromptArgument `json:"arguments"`
+}
+
+// promptData is the data a prompt template is executed with.
+type promptData struct {
+	Args map[string]string
+	Now  time.Time
+}
+
+// PromptTemplate is an MCP prompt rendered from a Go text/template. Templates fetch the data
+// they need through functions such as 'episode' and 'podcast', so the rendered prompt already
+// contains it.
+type PromptTemplate struct {
+	Prompt   mcp.Prompt
+	template *template.Template
+}
+
+// GetDefaultPromptsDir returns the default directory of user prompt templates.
+// Path is typically ~/.mcp/xiaoyuzhoufm-mcp/prompts
+func GetDefaultPromptsDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".mcp", "xiaoyuzhoufm-mcp", "prompts"), nil
+}
+
+// LoadPromptTemplates returns the built-in prompts plus the *.tmpl files in dir. A file named
+// like a built-in prompt replaces it. A missing dir yields the built-in prompts only; a file
+// that fails to parse is skipped with a warning.
+func LoadPromptTemplates(dir string) ([]*PromptTemplate, error) {
+	prompts := make(map[string]*PromptTemplate)
+
+	builtinFiles, err := fs.Glob(builtinPrompts, "prompts/*"+promptFileExt)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range builtinFiles {
+		text, err := builtinPrompts.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		pt, err := parsePromptTemplate(promptName(path), string(text))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse built-in prompt %s: %w", path, err)
+		}
+		prompts[pt.Prompt.Name] = pt
+	}
+
+	if dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read prompts directory %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != promptFileExt {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			text, err := os.ReadFile(path)
+			if err != nil {
+				slog.Warn("Skipped unreadable prompt template.", "path", path, "error", err)
+				continue
+			}
+			pt, err := parsePromptTemplate(promptName(path), string(text))
+			if err != nil {
+				slog.Warn("Skipped invalid prompt template.", "path", path, "error", err)
+				continue
+			}
+			if _, overridden := prompts[pt.Prompt.Name]; overridden {
+				slog.Info("Prompt template overridden.", "prompt", pt.Prompt.Name, "path", path)
+			} else {
+				slog.Info("Prompt template added.", "prompt", pt.Prompt.Name, "path", path)
+			}
+			prompts[pt.Prompt.Name] = pt
+		}
+	}
+
+	names := make([]string, 0, len(prompts))
+	for name := range prompts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]*PromptTemplate, 0, len(names))
+	for _, name := range names {
+		result = append(result, prompts[name])
+	}
+	return result, nil
+}
+
+func promptName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), promptFileExt)
+}
+
+// parsePromptTemplate splits off the metadata header and parses the rest as a template.
+func parsePromptTemplate(name, text string) (*PromptTemplate, error) {
+	var header promptHeader
+	body := strings.TrimLeft(text, " \t\r\n")
+	if rest, ok := strings.CutPrefix(body, promptHeaderStart); ok {
+		end := strings.Index(rest, promptHeaderEnd)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated metadata comment")
+		}
+		if err := json.Unmarshal([]byte(rest[:end]), &header); err != nil {
+			return nil, fmt.Errorf("invalid metadata: %w", err)
+		}
+		body = strings.TrimLeft(rest[end+len(promptHeaderEnd):], "\r\n")
+	}
+
+	// The functions are bound to the request context at execution time; these stubs only
+	// declare their names for parsing.
+	tmpl, err := template.New(name).Option("missingkey=zero").Funcs(promptFuncs(context.Background())).Parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := mcp.NewPrompt(name, mcp.WithPromptDescription(header.Description))
+	prompt.Arguments = header.Arguments
+	return &PromptTemplate{Prompt: prompt, template: tmpl}, nil
+}
+
+// Handler is the MCP handler function for the prompt.
+func (pt *PromptTemplate) Handler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	slog.Debug("Rendering prompt", "prompt", pt.Prompt.Name, "arguments", request.Params.Arguments)
+
+	args := make(map[string]string, len(request.Params.Arguments))
+	for name, value := range request.Params.Arguments {
+		args[name] = strings.TrimSpace(value)
+	}
+	for _, arg := range pt.Prompt.Arguments {
+		if arg.Required && args[arg.Name] == "" {
+			return nil, fmt.Errorf("missing required argument '%s'", arg.Name)
+		}
+	}
+
+	tmpl, err := pt.template.Clone()
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	data := promptData{Args: args, Now: time.Now().In(shanghaiLocation)}
+	if err := tmpl.Funcs(promptFuncs(ctx)).Execute(&sb, data); err != nil {
+		return nil, fmt.Errorf("failed to render prompt %s: %w", pt.Prompt.Name, err)
+	}
+
+	return mcp.NewGetPromptResult(pt.Prompt.Description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(strings.TrimSpace(sb.String()))),
+	}), nil
+}
+
+// promptFuncs returns the functions available to prompt templates. Data functions call the
+// Xiaoyuzhou API as the account in ctx.
+func promptFuncs(ctx context.Context) template.FuncMap {
+	return template.FuncMap{
+		"episode": func(episodeID string) (*xyzclient.Episode, error) {
+			episode, err := xyzclient.GetEpisodeDetailsByID(ctx, episodeID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get episode %s: %w", episodeID, err)
+			}
+			redactLockedMedia(episode)
+			return episode, nil
+		},
+		"podcast": func(podcastID string) (*xyzclient.PodcastDetailData, error) {
+			podcast, err := xyzclient.GetPodcastDetailsByID(ctx, podcastID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get podcast %s: %w", podcastID, err)
+			}
+			return podcast, nil
+		},
+		"episodes": func(podcastID string, limit int) ([]EpisodeSummary, error) {
+			episodes, err := xyzclient.ListPodcastEpisodes(ctx, xyzclient.EpisodeListRequest{
+				PID:   podcastID,
+				Order: "desc",
+				Limit: limit,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list episodes of podcast %s: %w", podcastID, err)
+			}
+			summaries := make([]EpisodeSummary, 0, len(episodes.Data))
+			for i := range episodes.Data {
+				summaries = append(summaries, newEpisodeSummary(&episodes.Data[i]))
+			}
+			return summaries, nil
+		},
+		"searchEpisodes": func(keyword string, limit int) ([]xyzclient.Episode, error) {
+			results, err := xyzclient.SearchEpisodes(ctx, keyword, "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search episodes for '%s': %w", keyword, err)
+			}
+			episodes := make([]xyzclient.Episode, 0, min(limit, len(results.Data)))
+			for i := 0; i < len(results.Data) && i < limit; i++ {
+				episode := xyzclient.Episode(results.Data[i])
+				redactLockedMedia(&episode)
+				episodes = append(episodes, episode)
+			}
+			return episodes, nil
+		},
+		"inbox": func(days int) ([]xyzclient.Episode, error) {
+			return listInboxSince(ctx, time.Now().AddDate(0, 0, -days))
+		},
+		"shownotes": func(episode *xyzclient.Episode) string {
+			notes := episode.Shownotes
+			if strings.TrimSpace(notes) == "" {
+				notes = episode.Description
+			}
+			return htmlToMarkdown(notes)
+		},
+		"date":     formatDate,
+		"minutes":  durationMinutes,
+		"truncate": func(maxRunes int, s string) string { return truncateText(s, maxRunes) },
+		"list": func(values ...any) []any {
+			return values
+		},
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}
+}
+
+// listInboxSince returns the inbox episodes published at or after since, newest first.
+func listInboxSince(ctx context.Context, since time.Time) ([]xyzclient.Episode, error) {
+	var episodes []xyzclient.Episode
+	apiRequest := xyzclient.InboxListRequest{Limit: 50}
+	for page := 0; page < maxInboxPages; page++ {
+		inboxData, err := xyzclient.ListInbox(ctx, apiRequest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list inbox: %w", err)
+		}
+		for i := range inboxData.Data {
+			if !isOnOrAfter(inboxData.Data[i].PubDate, since) {
+				return episodes, nil
+			}
+			redactLockedMedia(&inboxData.Data[i])
+			episodes = append(episodes, inboxData.Data[i])
+		}
+		if len(inboxData.Data) == 0 || inboxData.LoadMoreKey == nil {
+			break
+		}
+		apiRequest.LoadMoreKey = inboxData.LoadMoreKey
+	}
+	return episodes, nil
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RelationListResult is the result of the list_followers and list_following tools.
+type RelationListResult struct {
+	UID  string         `json:"uid"`
+	Data []UserOverview `json:"data"`
+	Page
+}
+
+// ListFollowersHandler is the MCP handler function for the list_followers tool.
+func ListFollowersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_followers tool", "arguments", request.Params.Arguments)
+	return listRelations(ctx, request, xyzclient.ListFollowers, "粉丝")
+}
+
+// ListFollowingHandler is the MCP handler function for the list_following tool.
+func ListFollowingHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_following tool", "arguments", request.Params.Arguments)
+	return listRelations(ctx, request, xyzclient.ListFollowing, "关注")
+}
+
+func listRelations(ctx context.Context, request mcp.CallToolRequest, fetch func(context.Context, xyzclient.RelationListRequest) (*xyzclient.RelationListResponse, error), label string) (*mcp.CallToolResult, error) {
+	userID, ok := request.GetArguments()["user_id"].(string)
+	if !ok || userID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'user_id' 不能为空且必须是字符串类型。"), nil
+	}
+	userID, err := resolveUserID(ctx, userID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("解析 'user_id' 失败", err), nil
+	}
+
+	apiRequest := xyzclient.RelationListRequest{
+		UID:   userID,
+		Limit: intArg(request.GetArguments(), "limit", 20, 50),
+	}
+	scope := cursorScope(userID)
+	if err := cursorArg(request, scope, &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	relationData, err := fetch(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取"+label+"列表失败", err), nil
+	}
+
+	result := RelationListResult{
+		UID:  userID,
+		Data: make([]UserOverview, 0, len(relationData.Data)),
+	}
+	for i := range relationData.Data {
+		result.Data = append(result.Data, newUserOverview(&relationData.Data[i]))
+	}
+
+	if result.Page, err = newPage(request, scope, relationData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取"+label+"列表", "userID", userID, "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
+
+// FollowUserHandler is the MCP handler function for the follow_user tool.
+func FollowUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing follow_user tool", "arguments", request.Params.Arguments)
+	return updateRelation(ctx, request, true)
+}
+
+// UnfollowUserHandler is the MCP handler function for the unfollow_user tool.
+func UnfollowUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing unfollow_user tool", "arguments", request.Params.Arguments)
+	return updateRelation(ctx, request, false)
+}
+
+func updateRelation(ctx context.Context, request mcp.CallToolRequest, follow bool) (*mcp.CallToolResult, error) {
+	userID, ok := request.GetArguments()["user_id"].(string)
+	if !ok || userID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'user_id' 不能为空且必须是字符串类型。"), nil
+	}
+	action := "取消关注用户"
+	if follow {
+		action = "关注用户"
+	}
+	if result := requireConfirmation(request, action); result != nil {
+		return result, nil
+	}
+
+	if err := xyzclient.UpdateUserRelation(ctx, userID, follow); err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API"+action+"失败", err), nil
+	}
+
+	slog.Info("User relation updated", "user_id", userID, "follow", follow)
+	return newActionResult("已" + action + " " + userID + "。"), nil
+}
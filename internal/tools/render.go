@@ -0,0 +1,601 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Output formats accepted by the 'format' argument.
+const (
+	FormatJSON     = "json"     // Compact JSON (default)
+	FormatMarkdown = "markdown" // Cards and tables for display to humans
+	FormatText     = "text"     // Plain text lines, for clients that do not render Markdown
+)
+
+// OutputFormats lists the accepted values of the 'format' argument.
+var OutputFormats = []string{FormatJSON, FormatMarkdown, FormatText}
+
+// resultRenderer turns a decoded tool result into its text content.
+type resultRenderer func(value interface{}) (string, error)
+
+// resultRenderers maps each output format to its renderer.
+var resultRenderers = map[string]resultRenderer{
+	FormatJSON: func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	FormatMarkdown: func(value interface{}) (string, error) {
+		return renderDocument(value, true), nil
+	},
+	FormatText: func(value interface{}) (string, error) {
+		return renderDocument(value, false), nil
+	},
+}
+
+// documentRenderer renders decoded JSON as Markdown or plain text. Podcasts, episodes and users
+// are recognized by their identifying keys (see entityPresets) and rendered as cards or tables;
+// everything else falls back to generic lists.
+type documentRenderer struct {
+	sb       strings.Builder
+	markdown bool
+	// highlights matches the search keywords bolded in titles and descriptions (Markdown only).
+	highlights *regexp.Regexp
+	keywords   map[string]bool
+}
+
+func renderDocument(value interface{}, markdown bool) string {
+	r := &documentRenderer{markdown: markdown}
+	if object, ok := value.(map[string]interface{}); ok {
+		r.highlights, r.keywords = highlightPattern(highlightWords(object))
+	}
+	r.render("", value, 0)
+	return strings.TrimSpace(r.sb.String()) + "\n"
+}
+
+// highlightWords extracts highlightWord.words from a search result.
+func highlightWords(object map[string]interface{}) []string {
+	highlight, _ := object["highlightWord"].(map[string]interface{})
+	words, _ := highlight["words"].([]interface{})
+	var result []string
+	for _, word := range words {
+		if s, ok := word.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// highlightPattern builds one alternation of the keywords, longest first so that "AI播客" wins
+// over "AI". URLs and Markdown link targets come before the keywords in the alternation, so a
+// keyword inside them is consumed as part of the URL and left alone.
+func highlightPattern(words []string) (*regexp.Regexp, map[string]bool) {
+	if len(words) == 0 {
+		return nil, nil
+	}
+	words = append([]string(nil), words...)
+	sort.SliceStable(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	keywords := make(map[string]bool, len(words))
+	alternatives := []string{`\]\([^)]*\)`, `https?://[^\s)\]]+`}
+	for _, word := range words {
+		keywords[word] = true
+		alternatives = append(alternatives, regexp.QuoteMeta(word))
+	}
+	return regexp.MustCompile(strings.Join(alternatives, "|")), keywords
+}
+
+func (r *documentRenderer) render(key string, value interface{}, depth int) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch entityKind(v) {
+		case "episode":
+			r.episodeCard(v, depth)
+		case "podcast":
+			r.podcastCard(v, depth)
+		case "user":
+			r.userCard(v, depth)
+		default:
+			r.object(v, depth)
+		}
+	case []interface{}:
+		r.list(v, depth)
+	default:
+		if key == "" {
+			r.sb.WriteString(scalarString(v) + "\n")
+		}
+	}
+}
+
+// object renders scalar fields as a list, then each nested value under its own heading.
+func (r *documentRenderer) object(object map[string]interface{}, depth int) {
+	keys := sortedKeys(object)
+	wroteFields := false
+	for _, key := range keys {
+		if key == "highlightWord" {
+			continue
+		}
+		if isInline(object[key]) {
+			value := r.inline(object[key])
+			// Cursors are passed back verbatim, so Markdown must not read their '_' as emphasis.
+			if key == "next_cursor" && r.markdown {
+				value = "`" + value + "`"
+			}
+			r.sb.WriteString(r.field(key, value))
+			wroteFields = true
+		}
+	}
+	if wroteFields {
+		r.sb.WriteString("\n")
+	}
+	for _, key := range keys {
+		if key == "highlightWord" || isInline(object[key]) || isEmptyValue(object[key]) {
+			continue
+		}
+		// The list payload of paged results needs no heading of its own.
+		if key != "data" {
+			r.heading(key, depth)
+		}
+		r.render(key, object[key], depth+1)
+	}
+}
+
+// list renders arrays of one entity kind as tables and other arrays item by item.
+func (r *documentRenderer) list(items []interface{}, depth int) {
+	if len(items) == 0 {
+		r.sb.WriteString("（无）\n\n")
+		return
+	}
+	var columns []column
+	switch commonKind(items) {
+	case "episode":
+		columns = []column{
+			{"标题", func(o map[string]interface{}) string { return r.highlight(stringField(o, "title")) }},
+			{"播客", func(o map[string]interface{}) string { return podcastTitle(o) }},
+			{"时长", func(o map[string]interface{}) string { return formatDuration(intField(o, "duration")) }},
+			{"发布时间", func(o map[string]interface{}) string { return formatDate(stringField(o, "pubDate")) }},
+			{"单集 ID", func(o map[string]interface{}) string { return stringField(o, "eid") }},
+		}
+	case "podcast":
+		columns = []column{
+			{"播客", func(o map[string]interface{}) string { return r.highlight(stringField(o, "title")) }},
+			{"主播", func(o map[string]interface{}) string { return stringField(o, "author") }},
+			{"订阅数", func(o map[string]interface{}) string { return scalarString(o["subscriptionCount"]) }},
+			{"最近更新", func(o map[string]interface{}) string { return formatDate(stringField(o, "latestEpisodePubDate")) }},
+			{"播客 ID", func(o map[string]interface{}) string { return stringField(o, "pid") }},
+		}
+	case "user":
+		columns = []column{
+			{"昵称", func(o map[string]interface{}) string { return r.highlight(stringField(o, "nickname")) }},
+			{"简介", func(o map[string]interface{}) string { return truncateText(stringField(o, "bio"), 60) }},
+			{"用户 ID", func(o map[string]interface{}) string { return stringField(o, "uid") }},
+		}
+	default:
+		if keys := flatObjectKeys(items); keys != nil {
+			columns = make([]column, len(keys))
+			for i, key := range keys {
+				columns[i] = column{key, func(o map[string]interface{}) string {
+					value := scalarString(o[key])
+					if !r.markdown && value != "" {
+						value = key + ": " + value // Plain text rows have no header
+					}
+					return value
+				}}
+			}
+		} else if allInline(items) {
+			for _, item := range items {
+				r.sb.WriteString(r.bullet(r.inline(item)))
+			}
+			r.sb.WriteString("\n")
+			return
+		}
+	}
+	if columns != nil && r.table(items, columns) {
+		return
+	}
+	for i, item := range items {
+		r.heading(fmt.Sprintf("%d", i+1), depth)
+		r.render("", item, depth+1)
+	}
+}
+
+// column is one column of an entity table.
+type column struct {
+	title string
+	value func(object map[string]interface{}) string
+}
+
+// table renders items as a table, or reports false without writing anything when none of the
+// columns has a value, so that the caller renders the items one by one instead.
+func (r *documentRenderer) table(items []interface{}, columns []column) bool {
+	// Columns missing from every row, e.g. after a field selection, are left out.
+	var present []column
+	for _, col := range columns {
+		for _, item := range items {
+			if col.value(item.(map[string]interface{})) != "" {
+				present = append(present, col)
+				break
+			}
+		}
+	}
+	if len(present) == 0 {
+		return false
+	}
+
+	if !r.markdown {
+		for _, item := range items {
+			var cells []string
+			for _, col := range present {
+				if cell := col.value(item.(map[string]interface{})); cell != "" {
+					cells = append(cells, cell)
+				}
+			}
+			r.sb.WriteString("- " + strings.Join(cells, " · ") + "\n")
+		}
+		r.sb.WriteString("\n")
+		return true
+	}
+
+	titles := make([]string, len(present))
+	separators := make([]string, len(present))
+	for i, col := range present {
+		titles[i] = col.title
+		separators[i] = "---"
+	}
+	r.sb.WriteString("| " + strings.Join(titles, " | ") + " |\n")
+	r.sb.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+	for _, item := range items {
+		cells := make([]string, len(present))
+		for i, col := range present {
+			cells[i] = escapeTableCell(col.value(item.(map[string]interface{})))
+		}
+		r.sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	r.sb.WriteString("\n")
+	return true
+}
+
+func (r *documentRenderer) episodeCard(episode map[string]interface{}, depth int) {
+	r.title(r.highlight(stringField(episode, "title")), depth)
+	if podcast := podcastTitle(episode); podcast != "" {
+		r.sb.WriteString(r.field("播客", podcast))
+	}
+	var facts []string
+	if date := formatDate(stringField(episode, "pubDate")); date != "" {
+		facts = append(facts, "发布于 "+date)
+	}
+	if duration := formatDuration(intField(episode, "duration")); duration != "" {
+		facts = append(facts, "时长 "+duration)
+	}
+	if len(facts) > 0 {
+		r.sb.WriteString(r.field("信息", strings.Join(facts, " · ")))
+	}
+	var stats []string
+	for _, stat := range []struct{ key, label string }{{"playCount", "播放"}, {"clapCount", "点赞"}, {"commentCount", "评论"}, {"favoriteCount", "收藏"}} {
+		if value, ok := episode[stat.key]; ok {
+			stats = append(stats, stat.label+" "+scalarString(value))
+		}
+	}
+	if len(stats) > 0 {
+		r.sb.WriteString(r.field("数据", strings.Join(stats, " · ")))
+	}
+	if status := stringField(episode, "accessStatus"); status != "" {
+		r.sb.WriteString(r.field("付费状态", status))
+	}
+	if enclosure, ok := episode["enclosure"].(map[string]interface{}); ok && stringField(enclosure, "url") != "" {
+		r.sb.WriteString(r.field("音频", stringField(enclosure, "url")))
+	}
+	r.sb.WriteString(r.field("单集 ID", stringField(episode, "eid")))
+	r.sb.WriteString("\n")
+
+	notes := stringField(episode, "shownotes")
+	if strings.TrimSpace(notes) == "" {
+		notes = stringField(episode, "description")
+	}
+	if notes != "" {
+		r.sb.WriteString(r.highlight(htmlToMarkdown(notes)) + "\n\n")
+	}
+}
+
+func (r *documentRenderer) podcastCard(podcast map[string]interface{}, depth int) {
+	r.title(r.highlight(stringField(podcast, "title")), depth)
+	if author := stringField(podcast, "author"); author != "" {
+		r.sb.WriteString(r.field("主播", author))
+	}
+	var facts []string
+	if _, ok := podcast["subscriptionCount"]; ok {
+		facts = append(facts, "订阅 "+scalarString(podcast["subscriptionCount"]))
+	}
+	if _, ok := podcast["episodeCount"]; ok {
+		facts = append(facts, "单集 "+scalarString(podcast["episodeCount"]))
+	}
+	if len(facts) > 0 {
+		r.sb.WriteString(r.field("数据", strings.Join(facts, " · ")))
+	}
+	if latest := formatDate(stringField(podcast, "latestEpisodePubDate")); latest != "" {
+		r.sb.WriteString(r.field("最新单集", latest))
+	}
+	r.sb.WriteString(r.field("播客 ID", stringField(podcast, "pid")))
+	r.sb.WriteString("\n")
+
+	if brief := stringField(podcast, "brief"); brief != "" {
+		if r.markdown {
+			brief = "> " + brief
+		}
+		r.sb.WriteString(r.highlight(brief) + "\n\n")
+	}
+	if description := stringField(podcast, "description"); description != "" {
+		r.sb.WriteString(r.highlight(description) + "\n\n")
+	}
+}
+
+func (r *documentRenderer) userCard(user map[string]interface{}, depth int) {
+	r.title(r.highlight(stringField(user, "nickname")), depth)
+	r.sb.WriteString(r.field("用户 ID", stringField(user, "uid")))
+	if relation := stringField(user, "relation"); relation != "" {
+		r.sb.WriteString(r.field("关系", relation))
+	}
+	r.sb.WriteString("\n")
+	if bio := stringField(user, "bio"); bio != "" {
+		r.sb.WriteString(bio + "\n\n")
+	}
+}
+
+func (r *documentRenderer) title(text string, depth int) {
+	if text == "" {
+		return
+	}
+	if r.markdown {
+		r.sb.WriteString(strings.Repeat("#", min(depth+2, 6)) + " " + text + "\n\n")
+	} else {
+		r.sb.WriteString(text + "\n")
+	}
+}
+
+func (r *documentRenderer) heading(text string, depth int) {
+	if r.markdown {
+		r.sb.WriteString(strings.Repeat("#", min(depth+2, 6)) + " " + text + "\n\n")
+	} else {
+		r.sb.WriteString("[" + text + "]\n")
+	}
+}
+
+func (r *documentRenderer) field(label, value string) string {
+	if r.markdown {
+		return "- **" + label + "**: " + value + "\n"
+	}
+	return label + ": " + value + "\n"
+}
+
+func (r *documentRenderer) bullet(text string) string {
+	return "- " + text + "\n"
+}
+
+// highlight bolds the search keywords in text. Plain text is returned unchanged.
+func (r *documentRenderer) highlight(text string) string {
+	if !r.markdown || text == "" || r.highlights == nil {
+		return text
+	}
+	return r.highlights.ReplaceAllStringFunc(text, func(match string) string {
+		if !r.keywords[match] {
+			return match // A URL or link target
+		}
+		return "**" + match + "**"
+	})
+}
+
+// entityKind classifies an object by the identifying keys of the detail presets plus the field
+// its card is titled with.
+func entityKind(object map[string]interface{}) string {
+	switch {
+	case hasKey(object, "eid") && hasKey(object, "title"):
+		return "episode"
+	case hasKey(object, "pid") && hasKey(object, "title"):
+		return "podcast"
+	case hasKey(object, "uid") && hasKey(object, "nickname"):
+		return "user"
+	}
+	return ""
+}
+
+// commonKind returns the entity kind shared by all items, or "" if they differ.
+func commonKind(items []interface{}) string {
+	kind := ""
+	for i, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		itemKind := entityKind(object)
+		if itemKind == "" || i > 0 && itemKind != kind {
+			return ""
+		}
+		kind = itemKind
+	}
+	return kind
+}
+
+func podcastTitle(episode map[string]interface{}) string {
+	if title := stringField(episode, "podcastTitle"); title != "" {
+		return title
+	}
+	if podcast, ok := episode["podcast"].(map[string]interface{}); ok {
+		return stringField(podcast, "title")
+	}
+	return ""
+}
+
+// isInline reports whether a value is shown on one line: scalars, and small objects or arrays
+// of scalars such as pagination keys.
+func isInline(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if entityKind(v) != "" {
+			return false
+		}
+		for _, child := range v {
+			if !isScalar(child) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		return len(v) > 0 && allScalar(v)
+	default:
+		return true
+	}
+}
+
+// flatObjectKeys returns the sorted union of keys when every item is an object of scalars,
+// so that the items can be shown as a table, and nil otherwise.
+func flatObjectKeys(items []interface{}) []string {
+	union := map[string]interface{}{}
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for key, value := range object {
+			if !isScalar(value) {
+				return nil
+			}
+			union[key] = nil
+		}
+	}
+	if len(union) == 0 {
+		return nil
+	}
+	return sortedKeys(union)
+}
+
+func allInline(items []interface{}) bool {
+	for _, item := range items {
+		if !isInline(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func allScalar(items []interface{}) bool {
+	for _, item := range items {
+		if !isScalar(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// inline renders an inline value. Small objects stay JSON, so that their structure stays
+// unambiguous on one line.
+func (r *documentRenderer) inline(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		data, _ := json.Marshal(v)
+		if r.markdown {
+			return "`" + string(data) + "`"
+		}
+		return string(data)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = scalarString(item)
+		}
+		return strings.Join(parts, "、")
+	default:
+		return scalarString(v)
+	}
+}
+
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "是"
+		}
+		return "否"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func stringField(object map[string]interface{}, key string) string {
+	s, _ := object[key].(string)
+	return s
+}
+
+func intField(object map[string]interface{}, key string) int {
+	switch v := object[key].(type) {
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+func hasKey(object map[string]interface{}, key string) bool {
+	_, ok := object[key]
+	return ok
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// formatDate formats an API timestamp as a date in Asia/Shanghai. Unparseable values are
+// returned unchanged.
+func formatDate(apiDate string) string {
+	t, err := time.Parse(time.RFC3339, apiDate)
+	if err != nil {
+		return apiDate
+	}
+	return t.In(shanghaiLocation).Format(time.DateOnly)
+}
+
+// durationMinutes rounds a duration in seconds up to whole minutes.
+func durationMinutes(seconds int) int {
+	return (seconds + 59) / 60
+}
+
+func formatDuration(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d 分钟", durationMinutes(seconds))
+}
+
+// truncateText collapses whitespace and cuts s to maxRunes characters.
+func truncateText(s string, maxRunes int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes]) + "…"
+}
@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderPodcastCard(t *testing.T) {
+	const podcast = `{"pid":"p1","title":"AI 播客","author":"主播甲","subscriptionCount":1200,"episodeCount":42,` +
+		`"latestEpisodePubDate":"2025-03-01T16:30:00.000Z","brief":"聊聊 AI","description":"长介绍"}`
+	tests := []struct {
+		name     string
+		markdown bool
+		want     string
+	}{
+		{"markdown", true, "## AI 播客\n\n- **主播**: 主播甲\n- **数据**: 订阅 1200 · 单集 42\n- **最新单集**: 2025-03-02\n- **播客 ID**: p1\n\n> 聊聊 AI\n\n长介绍\n"},
+		{"text", false, "AI 播客\n主播: 主播甲\n数据: 订阅 1200 · 单集 42\n最新单集: 2025-03-02\n播客 ID: p1\n\n聊聊 AI\n\n长介绍\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderDocument(mustDecode(t, podcast), tt.markdown); got != tt.want {
+				t.Errorf("renderDocument =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEpisodeTable(t *testing.T) {
+	const episodes = `{"data":[` +
+		`{"eid":"e1","title":"第一期","duration":3599,"pubDate":"2025-03-01T16:30:00.000Z","podcast":{"title":"P|Q"}},` +
+		`{"eid":"e2","title":"第二期","duration":61,"pubDate":"bad"}],"total":2}`
+	tests := []struct {
+		name     string
+		markdown bool
+		want     string
+	}{
+		{"markdown", true, "- **total**: 2\n\n| 标题 | 播客 | 时长 | 发布时间 | 单集 ID |\n| --- | --- | --- | --- | --- |\n" +
+			"| 第一期 | P\\|Q | 60 分钟 | 2025-03-02 | e1 |\n| 第二期 |  | 2 分钟 | bad | e2 |\n"},
+		{"text", false, "total: 2\n\n- 第一期 · P|Q · 60 分钟 · 2025-03-02 · e1\n- 第二期 · 2 分钟 · bad · e2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderDocument(mustDecode(t, episodes), tt.markdown); got != tt.want {
+				t.Errorf("renderDocument =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		markdown bool
+		want     string
+		notWant  string
+	}{
+		{"keyword in a table title", `{"data":[{"eid":"e1","title":"聊聊 AI"}],"highlightWord":{"words":["AI"]}}`, true, "| 聊聊 **AI** | e1 |", ""},
+		{"Markdown characters in the keyword", `{"data":[{"eid":"e1","title":"学 C++ 的一年"}],"highlightWord":{"words":["C++"]}}`, true, "学 **C++** 的一年", ""},
+		{"keyword in a card description", `{"pid":"p1","title":"P","description":"关于 AI 的节目","highlightWord":{"words":["AI"]}}`, true, "关于 **AI** 的节目", ""},
+		{"plain text is not bolded", `{"data":[{"eid":"e1","title":"聊聊 AI"}],"highlightWord":{"words":["AI"]}}`, false, "- 聊聊 AI · e1", "**"},
+		{"highlightWord itself is not rendered", `{"data":[{"eid":"e1","title":"AI"}],"highlightWord":{"words":["AI"]}}`, true, "**AI**", "words"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderDocument(mustDecode(t, tt.value), tt.markdown)
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("renderDocument =\n%s\nwant it to contain %q", got, tt.want)
+			}
+			if tt.notWant != "" && strings.Contains(got, tt.notWant) {
+				t.Errorf("renderDocument =\n%s\nwant no %q", got, tt.notWant)
+			}
+		})
+	}
+}
+
+func TestRenderFallback(t *testing.T) {
+	const value = `{"items":[1,"two",true],"nested":{"a":{"b":1}},"list":[{"x":{"y":1}},{"x":2}],"message":"ok"}`
+	tests := []struct {
+		name     string
+		value    string
+		markdown bool
+		want     string
+	}{
+		{"markdown", value, true, "- **items**: 1、two、是\n- **message**: ok\n\n## list\n\n### 1\n\n- **x**: `{\"y\":1}`\n\n### 2\n\n- **x**: 2\n\n## nested\n\n- **a**: `{\"b\":1}`\n"},
+		{"text", value, false, "items: 1、two、是\nmessage: ok\n\n[list]\n[1]\nx: {\"y\":1}\n\n[2]\nx: 2\n\n[nested]\na: {\"b\":1}\n"},
+		{"empty list", `[]`, true, "（无）\n"},
+		{"flat objects become a table", `{"data":[{"k":"a","v":1},{"k":"b"}]}`, true, "| k | v |\n| --- | --- |\n| a | 1 |\n| b |  |\n"},
+		{"flat objects as text", `{"data":[{"k":"a","v":1},{"k":"b"}]}`, false, "- k: a · v: 1\n- k: b\n"},
+		{"scalar", `"done"`, true, "done\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderDocument(mustDecode(t, tt.value), tt.markdown); got != tt.want {
+				t.Errorf("renderDocument =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, ""},
+		{-5, ""},
+		{1, "1 分钟"},
+		{60, "1 分钟"},
+		{61, "2 分钟"},
+		{3600, "60 分钟"},
+	}
+	for _, tt := range tests {
+		if got := formatDuration(tt.seconds); got != tt.want {
+			t.Errorf("formatDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestRenderHighlightOverlapsAndLinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		text  string
+		want  string
+	}{
+		{"longest keyword wins", []string{"AI", "AI播客"}, "聊聊AI播客和AI", "聊聊**AI播客**和**AI**"},
+		{"order of keywords does not matter", []string{"AI播客", "AI"}, "聊聊AI播客和AI", "聊聊**AI播客**和**AI**"},
+		{"regexp characters", []string{"C", "C++"}, "C++ 与 C", "**C++** 与 **C**"},
+		{"link target is left alone", []string{"AI"}, "[AI 周刊](https://x.com/AI)", "[**AI** 周刊](https://x.com/AI)"},
+		{"bare URL is left alone", []string{"AI"}, "见 https://a.com/AI?q=AI 和 AI", "见 https://a.com/AI?q=AI 和 **AI**"},
+		{"no keywords", nil, "AI", "AI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &documentRenderer{markdown: true}
+			r.highlights, r.keywords = highlightPattern(tt.words)
+			if got := r.highlight(tt.text); got != tt.want {
+				t.Errorf("highlight(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTableWithoutValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		markdown bool
+		want     []string // Parts of the output, in order
+	}{
+		{"episodes without any column", `[{"eid":"","title":"","description":"d1"},{"eid":"","title":"","description":"d2"}]`, true,
+			[]string{"## 1", "d1", "## 2", "d2"}},
+		{"flat objects without values", `[{"a":""},{"a":null}]`, true, []string{"## 1", "**a**", "## 2", "**a**"}},
+		{"plain text", `[{"a":""}]`, false, []string{"[1]", "a:"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderDocument(mustDecode(t, tt.value), tt.markdown)
+			if strings.Contains(got, "|") {
+				t.Errorf("renderDocument rendered an empty table:\n%s", got)
+			}
+			rest := got
+			for _, part := range tt.want {
+				i := strings.Index(rest, part)
+				if i < 0 {
+					t.Fatalf("renderDocument =\n%s\nwant %q in order", got, tt.want)
+				}
+				rest = rest[i+len(part):]
+			}
+		})
+	}
+}
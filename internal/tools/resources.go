@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// URI templates of the resources exposed by the server.
+const (
+	PodcastResourceTemplate          = "xyz://podcast/{pid}"
+	PodcastEpisodesResourceTemplate  = "xyz://podcast/{pid}/episodes"
+	EpisodeResourceTemplate          = "xyz://episode/{eid}"
+	EpisodeShownotesResourceTemplate = "xyz://episode/{eid}/shownotes"
+	UserResourceTemplate             = "xyz://user/{uid}"
+)
+
+// MIME types of resource contents.
+const (
+	mimeTypeJSON     = "application/json"
+	mimeTypeMarkdown = "text/markdown"
+)
+
+// podcastEpisodesResourceLimit is how many of the newest episodes xyz://podcast/{pid}/episodes returns.
+const podcastEpisodesResourceLimit = 20
+
+// PodcastEpisodesResource is the content of xyz://podcast/{pid}/episodes.
+type PodcastEpisodesResource struct {
+	PID      string           `json:"pid"`
+	Title    string           `json:"title"`
+	Episodes []EpisodeSummary `json:"episodes"`
+}
+
+// PodcastEpisodesURI returns the resource URI listing the newest episodes of a podcast.
+func PodcastEpisodesURI(podcastID string) string {
+	return "xyz://podcast/" + podcastID + "/episodes"
+}
+
+// ReadPodcastResource is the MCP handler function for the xyz://podcast/{pid} resource.
+func ReadPodcastResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	slog.Debug("Reading podcast resource", "uri", request.Params.URI)
+
+	podcastID, err := resourceArg(request, "pid")
+	if err != nil {
+		return nil, err
+	}
+	podcast, err := xyzclient.GetPodcastDetailsByID(ctx, podcastID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get podcast %s: %w", podcastID, err)
+	}
+	return jsonResourceContents(request.Params.URI, podcast)
+}
+
+// ReadPodcastEpisodesResource is the MCP handler function for the xyz://podcast/{pid}/episodes resource.
+func ReadPodcastEpisodesResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	slog.Debug("Reading podcast episodes resource", "uri", request.Params.URI)
+
+	podcastID, err := resourceArg(request, "pid")
+	if err != nil {
+		return nil, err
+	}
+	episodes, err := xyzclient.ListPodcastEpisodes(ctx, xyzclient.EpisodeListRequest{
+		PID:   podcastID,
+		Order: "desc",
+		Limit: podcastEpisodesResourceLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list episodes of podcast %s: %w", podcastID, err)
+	}
+
+	result := PodcastEpisodesResource{PID: podcastID, Episodes: make([]EpisodeSummary, 0, len(episodes.Data))}
+	for i := range episodes.Data {
+		if result.Title == "" {
+			result.Title = episodes.Data[i].Podcast.Title
+		}
+		result.Episodes = append(result.Episodes, newEpisodeSummary(&episodes.Data[i]))
+	}
+	return jsonResourceContents(request.Params.URI, result)
+}
+
+// ReadEpisodeResource is the MCP handler function for the xyz://episode/{eid} resource.
+func ReadEpisodeResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	slog.Debug("Reading episode resource", "uri", request.Params.URI)
+
+	episodeID, err := resourceArg(request, "eid")
+	if err != nil {
+		return nil, err
+	}
+	episode, err := xyzclient.GetEpisodeDetailsByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episode %s: %w", episodeID, err)
+	}
+	redactLockedMedia(episode)
+	return jsonResourceContents(request.Params.URI, episode)
+}
+
+// ReadEpisodeShownotesResource is the MCP handler function for the xyz://episode/{eid}/shownotes
+// resource. It renders the shownotes as Markdown with a short header identifying the episode.
+func ReadEpisodeShownotesResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	slog.Debug("Reading episode shownotes resource", "uri", request.Params.URI)
+
+	episodeID, err := resourceArg(request, "eid")
+	if err != nil {
+		return nil, err
+	}
+	episode, err := xyzclient.GetEpisodeDetailsByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episode %s: %w", episodeID, err)
+	}
+
+	notes := episode.Shownotes
+	if strings.TrimSpace(notes) == "" {
+		notes = episode.Description
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", episode.Title)
+	fmt.Fprintf(&sb, "- 播客: %s\n", episode.Podcast.Title)
+	if episode.PubDate != "" {
+		fmt.Fprintf(&sb, "- 发布时间: %s\n", episode.PubDate)
+	}
+	if episode.Duration > 0 {
+		fmt.Fprintf(&sb, "- 时长: %d 分钟\n", (episode.Duration+59)/60)
+	}
+	sb.WriteString("\n")
+	sb.WriteString(htmlToMarkdown(notes))
+	sb.WriteString("\n")
+
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      request.Params.URI,
+		MIMEType: mimeTypeMarkdown,
+		Text:     sb.String(),
+	}}, nil
+}
+
+// ReadUserResource is the MCP handler function for the xyz://user/{uid} resource.
+// The "me" alias resolves to the logged-in user.
+func ReadUserResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	slog.Debug("Reading user resource", "uri", request.Params.URI)
+
+	userID, err := resourceArg(request, "uid")
+	if err != nil {
+		return nil, err
+	}
+	userID, err = resolveUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := xyzclient.GetUserProfileByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", userID, err)
+	}
+	return jsonResourceContents(request.Params.URI, profile)
+}
+
+// resourceArg returns a variable matched from the resource URI template.
+func resourceArg(request mcp.ReadResourceRequest, name string) (string, error) {
+	var value string
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		value = v
+	case []string:
+		if len(v) > 0 {
+			value = v[0]
+		}
+	}
+	if value == "" || strings.Contains(value, "/") {
+		return "", fmt.Errorf("invalid resource URI %s: missing '%s'", request.Params.URI, name)
+	}
+	return value, nil
+}
+
+// jsonResourceContents marshals v as the single JSON content of a resource.
+func jsonResourceContents(uri string, v interface{}) ([]mcp.ResourceContents, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource %s: %w", uri, err)
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      uri,
+		MIMEType: mimeTypeJSON,
+		Text:     string(data),
+	}}, nil
+}
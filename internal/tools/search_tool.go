@@ -2,7 +2,6 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 
 	"xiaoyuzhoufm-mcp/internal/xyzclient"
@@ -10,113 +9,161 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// SearchResult is the result of the search_podcasts, search_episodes and search_users tools.
+type SearchResult[T any] struct {
+	Data          []T                      `json:"data"`
+	HighlightWord *xyzclient.HighlightWord `json:"highlightWord,omitempty"`
+	Page
+}
+
+// newSearchResult wraps a page of search results, hiding the API's paging key in the cursor.
+func newSearchResult[T any](request mcp.CallToolRequest, scope string, data []T, highlight *xyzclient.HighlightWord, loadMoreKey *xyzclient.SearchAPILoadMoreKey) (*SearchResult[T], error) {
+	result := &SearchResult[T]{Data: data, HighlightWord: highlight}
+	var nextKey interface{}
+	if loadMoreKey != nil && loadMoreKey.LoadMoreKey != nil && len(data) > 0 {
+		nextKey = loadMoreKey
+	}
+	page, err := newPage(request, scope, nextKey)
+	if err != nil {
+		return nil, err
+	}
+	result.Page = page
+	return result, nil
+}
+
 // SearchPodcastsHandler is the MCP handler function for the search_podcasts tool.
 func SearchPodcastsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	slog.Debug("Executing search_podcasts tool", "arguments", request.Params.Arguments)
 
-	keyword, ok := request.Params.Arguments["keyword"].(string)
+	keyword, ok := request.GetArguments()["keyword"].(string)
 	if !ok || keyword == "" {
 		return mcp.NewToolResultError("参数 'keyword' 不能为空且必须是字符串类型。"), nil
 	}
 
+	scope := cursorScope(keyword)
 	var loadMoreKey *xyzclient.SearchAPILoadMoreKey
-	if lmkMap, ok := request.Params.Arguments["load_more_key"].(map[string]interface{}); ok && lmkMap != nil {
-		lmk := &xyzclient.SearchAPILoadMoreKey{}
-		if lmkVal, okGet := lmkMap["loadMoreKey"]; okGet { // API uses "loadMoreKey" (interface{}) inside the object
-			lmk.LoadMoreKey = lmkVal
-		}
-		if searchID, okGet := lmkMap["searchId"].(string); okGet {
-			lmk.SearchID = searchID
-		}
-		// Only assign if at least one field was present, or if the object itself was non-nil
-		if lmk.LoadMoreKey != nil || lmk.SearchID != "" {
-			loadMoreKey = lmk
-		}
-	}
-
-	searchResult, err := xyzclient.SearchPodcasts(keyword, loadMoreKey)
+	if err := cursorArg(request, scope, &loadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	searchResult, err := xyzclient.SearchPodcasts(ctx, keyword, loadMoreKey)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("调用API搜索播客失败", err), nil
 	}
 
-	resultJSON, err := json.Marshal(searchResult)
+	result, err := newSearchResult(request, scope, searchResult.Data, searchResult.HighlightWord, searchResult.LoadMoreKey)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("处理播客搜索结果失败", err), nil
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
 	}
-	slog.Debug("成功搜索播客", "keyword", keyword, "count", len(searchResult.Data))
-	return mcp.NewToolResultText(string(resultJSON)), nil
+
+	slog.Debug("成功搜索播客", "keyword", keyword, "count", len(result.Data))
+	return newToolResult(request, result), nil
 }
 
 // SearchEpisodesHandler is the MCP handler function for the search_episodes tool.
 func SearchEpisodesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	slog.Debug("Executing search_episodes tool", "arguments", request.Params.Arguments)
 
-	keyword, ok := request.Params.Arguments["keyword"].(string)
+	keyword, ok := request.GetArguments()["keyword"].(string)
 	if !ok || keyword == "" {
 		return mcp.NewToolResultError("参数 'keyword' 不能为空且必须是字符串类型。"), nil
 	}
 
-	pid, _ := request.Params.Arguments["pid"].(string) // pid is optional for this tool
+	pid, _ := request.GetArguments()["pid"].(string) // pid is optional for this tool
 
+	scope := cursorScope(keyword, pid)
 	var loadMoreKey *xyzclient.SearchAPILoadMoreKey
-	if lmkMap, ok := request.Params.Arguments["load_more_key"].(map[string]interface{}); ok && lmkMap != nil {
-		lmk := &xyzclient.SearchAPILoadMoreKey{}
-		if lmkVal, okGet := lmkMap["loadMoreKey"]; okGet {
-			lmk.LoadMoreKey = lmkVal
-		}
-		if searchID, okGet := lmkMap["searchId"].(string); okGet {
-			lmk.SearchID = searchID
-		}
-		if lmk.LoadMoreKey != nil || lmk.SearchID != "" {
-			loadMoreKey = lmk
-		}
-	}
-
-	searchResult, err := xyzclient.SearchEpisodes(keyword, pid, loadMoreKey)
+	if err := cursorArg(request, scope, &loadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	searchResult, err := xyzclient.SearchEpisodes(ctx, keyword, pid, loadMoreKey)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("调用API搜索单集失败", err), nil
 	}
+	for i := range searchResult.Data {
+		redactLockedMedia((*xyzclient.Episode)(&searchResult.Data[i]))
+	}
 
-	resultJSON, err := json.Marshal(searchResult)
+	result, err := newSearchResult(request, scope, searchResult.Data, searchResult.HighlightWord, searchResult.LoadMoreKey)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("处理单集搜索结果失败", err), nil
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
 	}
-	slog.Debug("成功搜索单集", "keyword", keyword, "pid", pid, "count", len(searchResult.Data))
-	return mcp.NewToolResultText(string(resultJSON)), nil
+
+	slog.Debug("成功搜索单集", "keyword", keyword, "pid", pid, "count", len(result.Data))
+	return newToolResult(request, result), nil
 }
 
 // SearchUsersHandler is the MCP handler function for the search_users tool.
 func SearchUsersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	slog.Debug("Executing search_users tool", "arguments", request.Params.Arguments)
 
-	keyword, ok := request.Params.Arguments["keyword"].(string)
+	keyword, ok := request.GetArguments()["keyword"].(string)
 	if !ok || keyword == "" {
 		return mcp.NewToolResultError("参数 'keyword' 不能为空且必须是字符串类型。"), nil
 	}
 
+	scope := cursorScope(keyword)
 	var loadMoreKey *xyzclient.SearchAPILoadMoreKey
-	if lmkMap, ok := request.Params.Arguments["load_more_key"].(map[string]interface{}); ok && lmkMap != nil {
-		lmk := &xyzclient.SearchAPILoadMoreKey{}
-		if lmkVal, okGet := lmkMap["loadMoreKey"]; okGet {
-			lmk.LoadMoreKey = lmkVal
-		}
-		if searchID, okGet := lmkMap["searchId"].(string); okGet {
-			lmk.SearchID = searchID
-		}
-		if lmk.LoadMoreKey != nil || lmk.SearchID != "" {
-			loadMoreKey = lmk
-		}
-	}
-
-	searchResult, err := xyzclient.SearchUsers(keyword, loadMoreKey)
+	if err := cursorArg(request, scope, &loadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	searchResult, err := xyzclient.SearchUsers(ctx, keyword, loadMoreKey)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("调用API搜索用户失败", err), nil
 	}
 
-	resultJSON, err := json.Marshal(searchResult)
+	result, err := newSearchResult(request, scope, searchResult.Data, searchResult.HighlightWord, searchResult.LoadMoreKey)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("处理用户搜索结果失败", err), nil
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
 	}
-	slog.Debug("成功搜索用户", "keyword", keyword, "count", len(searchResult.Data))
-	return mcp.NewToolResultText(string(resultJSON)), nil
+
+	slog.Debug("成功搜索用户", "keyword", keyword, "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
+
+// HotSearchesResult is the result of the get_hot_searches tool.
+type HotSearchesResult struct {
+	HotWords []xyzclient.HotSearchWord `json:"hotWords"`
+	Presets  []xyzclient.SearchPreset  `json:"presets,omitempty"`
+}
+
+// SuggestSearchTermsHandler is the MCP handler function for the suggest_search_terms tool.
+func SuggestSearchTermsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing suggest_search_terms tool", "arguments", request.Params.Arguments)
+
+	keyword, ok := request.GetArguments()["keyword"].(string)
+	if !ok || keyword == "" {
+		return mcp.NewToolResultError("参数 'keyword' 不能为空且必须是字符串类型。"), nil
+	}
+
+	suggestions, err := xyzclient.GetSearchSuggestions(ctx, keyword)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取搜索建议失败", err), nil
+	}
+
+	slog.Debug("成功获取搜索建议", "keyword", keyword, "count", len(suggestions))
+	return newToolResult(request, suggestions), nil
+}
+
+// GetHotSearchesHandler is the MCP handler function for the get_hot_searches tool.
+func GetHotSearchesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing get_hot_searches tool", "arguments", request.Params.Arguments)
+
+	hotWords, err := xyzclient.GetHotSearches(ctx)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取热门搜索失败", err), nil
+	}
+	result := HotSearchesResult{HotWords: hotWords}
+
+	// Preset queries are a nice-to-have; a failure here should not hide the hot words.
+	if presets, err := xyzclient.GetSearchPresets(ctx); err != nil {
+		slog.Warn("Failed to fetch search presets.", "error", err)
+	} else {
+		result.Presets = presets
+	}
+
+	slog.Debug("成功获取热门搜索", "count", len(result.HotWords), "presets", len(result.Presets))
+	return newToolResult(request, result), nil
 }
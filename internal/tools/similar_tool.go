@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Weights and bounds of the local similarity fallback used by find_similar_podcasts.
+const (
+	sharedPodcasterWeight = 3
+	sharedLabelWeight     = 2
+	briefMatchWeight      = 1
+	maxLabelSearches      = 3
+	maxPodcasterLookups   = 3
+	briefKeywordRunes     = 12
+)
+
+// SimilarPodcast is one entry of the find_similar_podcasts result.
+type SimilarPodcast struct {
+	PodcastOverview
+	Score   int      `json:"score,omitempty"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// SimilarPodcastsResult is the result of the find_similar_podcasts tool.
+type SimilarPodcastsResult struct {
+	PID    string           `json:"pid"`
+	Source string           `json:"source"` // "platform" or "local"
+	Data   []SimilarPodcast `json:"data"`
+}
+
+// similarityCandidate accumulates the evidence that a podcast is similar to the source podcast.
+type similarityCandidate struct {
+	podcast xyzclient.AuthorshipEntry
+	score   int
+	reasons []string
+}
+
+// FindSimilarPodcastsHandler is the MCP handler function for the find_similar_podcasts tool.
+func FindSimilarPodcastsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing find_similar_podcasts tool", "arguments", request.Params.Arguments)
+
+	podcastID, ok := request.GetArguments()["podcast_id"].(string)
+	if !ok || podcastID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'podcast_id' 不能为空且必须是字符串类型。"), nil
+	}
+	limit := intArg(request.GetArguments(), "limit", 10, 30)
+
+	result := SimilarPodcastsResult{PID: podcastID, Source: "platform"}
+
+	related, err := xyzclient.GetRelatedPodcasts(ctx, podcastID)
+	if err != nil {
+		// The recommendation endpoint is best effort; the local fallback can still answer.
+		slog.Warn("Failed to fetch related podcasts, falling back to local similarity.", "podcast_id", podcastID, "error", err)
+	}
+	for i := range related {
+		if len(result.Data) >= limit {
+			break
+		}
+		if related[i].PID == podcastID {
+			continue
+		}
+		result.Data = append(result.Data, SimilarPodcast{PodcastOverview: newPodcastOverview(&related[i])})
+	}
+
+	if len(result.Data) == 0 {
+		result.Source = "local"
+		result.Data, err = findSimilarPodcastsLocally(ctx, podcastID, limit)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("计算相似播客失败", err), nil
+		}
+	}
+
+	slog.Debug("成功获取相似播客", "podcast_id", podcastID, "source", result.Source, "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
+
+// findSimilarPodcastsLocally scores candidate podcasts by shared podcasters, shared topic labels
+// and a keyword search on the source podcast's brief.
+func findSimilarPodcastsLocally(ctx context.Context, podcastID string, limit int) ([]SimilarPodcast, error) {
+	source, err := xyzclient.GetPodcastDetailsByID(ctx, podcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]*similarityCandidate)
+	addCandidate := func(podcast xyzclient.AuthorshipEntry, weight int, reason string) {
+		if podcast.PID == "" || podcast.PID == podcastID {
+			return
+		}
+		candidate, ok := candidates[podcast.PID]
+		if !ok {
+			candidate = &similarityCandidate{podcast: podcast}
+			candidates[podcast.PID] = candidate
+		}
+		for _, existing := range candidate.reasons {
+			if existing == reason {
+				return
+			}
+		}
+		candidate.score += weight
+		candidate.reasons = append(candidate.reasons, reason)
+	}
+
+	// Other shows hosted by the same podcasters.
+	for i, podcaster := range source.Podcasters {
+		if i >= maxPodcasterLookups {
+			break
+		}
+		profile, err := xyzclient.GetUserProfileByID(ctx, podcaster.UID)
+		if err != nil {
+			slog.Warn("Failed to fetch podcaster profile for similarity.", "uid", podcaster.UID, "error", err)
+			continue
+		}
+		for _, show := range profile.Authorship {
+			addCandidate(show, sharedPodcasterWeight, "共同主播: "+podcaster.Nickname)
+		}
+	}
+
+	// Podcasts found by searching the source's topic labels; only shared labels count.
+	sourceLabels := make(map[string]bool, len(source.TopicLabels))
+	for _, label := range source.TopicLabels {
+		sourceLabels[label] = true
+	}
+	for i, label := range source.TopicLabels {
+		if i >= maxLabelSearches {
+			break
+		}
+		searchResult, err := xyzclient.SearchPodcasts(ctx, label, nil)
+		if err != nil {
+			slog.Warn("Failed to search podcasts by topic label for similarity.", "label", label, "error", err)
+			continue
+		}
+		for _, item := range searchResult.Data {
+			for _, candidateLabel := range item.TopicLabels {
+				if sourceLabels[candidateLabel] {
+					addCandidate(xyzclient.AuthorshipEntry(item), sharedLabelWeight, "相同标签: "+candidateLabel)
+				}
+			}
+		}
+	}
+
+	// Podcasts matching a keyword taken from the brief.
+	if keyword := briefKeyword(source.Brief); keyword != "" {
+		searchResult, err := xyzclient.SearchPodcasts(ctx, keyword, nil)
+		if err != nil {
+			slog.Warn("Failed to search podcasts by brief for similarity.", "keyword", keyword, "error", err)
+		} else {
+			for _, item := range searchResult.Data {
+				addCandidate(xyzclient.AuthorshipEntry(item), briefMatchWeight, "简介关键词匹配: "+keyword)
+			}
+		}
+	}
+
+	ranked := make([]*similarityCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		ranked = append(ranked, candidate)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].podcast.SubscriptionCount > ranked[j].podcast.SubscriptionCount
+	})
+
+	similar := make([]SimilarPodcast, 0, min(limit, len(ranked)))
+	for _, candidate := range ranked {
+		if len(similar) >= limit {
+			break
+		}
+		similar = append(similar, SimilarPodcast{
+			PodcastOverview: newPodcastOverviewFromAuthorship(&candidate.podcast),
+			Score:           candidate.score,
+			Reasons:         candidate.reasons,
+		})
+	}
+	return similar, nil
+}
+
+// briefKeyword takes the first clause of a brief, capped in length, as a search keyword.
+func briefKeyword(brief string) string {
+	brief = strings.TrimSpace(brief)
+	if i := strings.IndexAny(brief, "，。,.！!？?；;\n"); i >= 0 {
+		brief = brief[:i]
+	}
+	if utf8.RuneCountInString(brief) > briefKeywordRunes {
+		brief = string([]rune(brief)[:briefKeywordRunes])
+	}
+	return strings.TrimSpace(brief)
+}
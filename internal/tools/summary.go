@@ -0,0 +1,86 @@
+package tools
+
+import "xiaoyuzhoufm-mcp/internal/xyzclient"
+
+// EpisodeSummary is a compact view of an episode for list-style tool results.
+type EpisodeSummary struct {
+	EID          string `json:"eid"`
+	Title        string `json:"title"`
+	PID          string `json:"pid"`
+	PodcastTitle string `json:"podcastTitle"`
+	PubDate      string `json:"pubDate"`
+	Duration     int    `json:"duration"` // Seconds
+	IsPlayed     bool   `json:"isPlayed"`
+	IsFinished   bool   `json:"isFinished"`
+	// AccessStatus is only set for paid episodes, so free content stays compact.
+	AccessStatus xyzclient.AccessStatus `json:"accessStatus,omitempty"`
+}
+
+func newEpisodeSummary(episode *xyzclient.Episode) EpisodeSummary {
+	summary := EpisodeSummary{
+		EID:          episode.EID,
+		Title:        episode.Title,
+		PID:          episode.PID,
+		PodcastTitle: episode.Podcast.Title,
+		PubDate:      episode.PubDate,
+		Duration:     episode.Duration,
+		IsPlayed:     episode.IsPlayed,
+		IsFinished:   episode.IsFinished,
+	}
+	if episode.IsPaid() {
+		summary.AccessStatus = episode.ComputeAccessStatus()
+	}
+	return summary
+}
+
+// PodcastOverview is a compact view of a podcast for list-style tool results.
+type PodcastOverview struct {
+	PID                  string `json:"pid"`
+	Title                string `json:"title"`
+	Author               string `json:"author"`
+	Brief                string `json:"brief"`
+	SubscriptionCount    int    `json:"subscriptionCount"`
+	EpisodeCount         int    `json:"episodeCount"`
+	LatestEpisodePubDate string `json:"latestEpisodePubDate"`
+}
+
+func newPodcastOverview(podcast *xyzclient.PodcastSummary) PodcastOverview {
+	return PodcastOverview{
+		PID:                  podcast.PID,
+		Title:                podcast.Title,
+		Author:               podcast.Author,
+		Brief:                podcast.Brief,
+		SubscriptionCount:    podcast.SubscriptionCount,
+		EpisodeCount:         podcast.EpisodeCount,
+		LatestEpisodePubDate: podcast.LatestEpisodePubDate,
+	}
+}
+
+// UserOverview is a compact view of a user for list-style tool results.
+type UserOverview struct {
+	UID      string `json:"uid"`
+	Nickname string `json:"nickname"`
+	Bio      string `json:"bio,omitempty"`
+	Relation string `json:"relation,omitempty"`
+}
+
+func newUserOverview(user *xyzclient.RelationUser) UserOverview {
+	return UserOverview{
+		UID:      user.UID,
+		Nickname: user.Nickname,
+		Bio:      user.Bio,
+		Relation: user.Relation,
+	}
+}
+
+func newPodcastOverviewFromAuthorship(podcast *xyzclient.AuthorshipEntry) PodcastOverview {
+	return PodcastOverview{
+		PID:                  podcast.PID,
+		Title:                podcast.Title,
+		Author:               podcast.Author,
+		Brief:                podcast.Brief,
+		SubscriptionCount:    podcast.SubscriptionCount,
+		EpisodeCount:         podcast.EpisodeCount,
+		LatestEpisodePubDate: podcast.LatestEpisodePubDate,
+	}
+}
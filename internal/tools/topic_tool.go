@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TopicPostView is one entry of the list_topic_posts result.
+type TopicPostView struct {
+	ID           string          `json:"id"`
+	Content      string          `json:"content"`
+	CreatedAt    string          `json:"createdAt"`
+	AuthorUID    string          `json:"authorUid"`
+	AuthorName   string          `json:"authorName"`
+	LikeCount    int             `json:"likeCount"`
+	CommentCount int             `json:"commentCount"`
+	PictureCount int             `json:"pictureCount,omitempty"`
+	Episode      *EpisodeSummary `json:"episode,omitempty"`
+}
+
+// TopicPostListResult is the result of the list_topic_posts tool.
+type TopicPostListResult struct {
+	TopicID string          `json:"topicId"`
+	Data    []TopicPostView `json:"data"`
+	Page
+}
+
+// GetTopicHandler is the MCP handler function for the get_topic tool.
+func GetTopicHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing get_topic tool", "arguments", request.Params.Arguments)
+
+	topicID, _ := request.GetArguments()["topic_id"].(string)
+	podcastID, _ := request.GetArguments()["podcast_id"].(string)
+
+	var topic *xyzclient.Topic
+	var err error
+	switch {
+	case topicID != "":
+		topic, err = xyzclient.GetTopic(ctx, topicID)
+	case podcastID != "":
+		topic, err = xyzclient.GetPodcastTopic(ctx, podcastID)
+	default:
+		return mcp.NewToolResultError("错误: 必须提供 'topic_id' 或 'podcast_id' 其中之一。"), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取圈子详情失败", err), nil
+	}
+
+	slog.Debug("成功获取圈子详情", "topic_id", topic.ID, "name", topic.Name)
+	return newToolResult(request, topic), nil
+}
+
+// ListTopicPostsHandler is the MCP handler function for the list_topic_posts tool.
+func ListTopicPostsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing list_topic_posts tool", "arguments", request.Params.Arguments)
+
+	topicID, ok := request.GetArguments()["topic_id"].(string)
+	if !ok || topicID == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'topic_id' 不能为空且必须是字符串类型。"), nil
+	}
+
+	apiRequest := xyzclient.TopicPostListRequest{
+		TopicID: topicID,
+		Limit:   intArg(request.GetArguments(), "limit", 20, 50),
+	}
+	scope := cursorScope(topicID)
+	if err := cursorArg(request, scope, &apiRequest.LoadMoreKey); err != nil {
+		return mcp.NewToolResultError("错误: 输入参数 'cursor' " + err.Error()), nil
+	}
+
+	postsData, err := xyzclient.ListTopicPosts(ctx, apiRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取圈子帖子失败", err), nil
+	}
+
+	result := TopicPostListResult{
+		TopicID: topicID,
+		Data:    make([]TopicPostView, 0, len(postsData.Data)),
+	}
+	for i := range postsData.Data {
+		post := &postsData.Data[i]
+		view := TopicPostView{
+			ID:           post.ID,
+			Content:      post.Content,
+			CreatedAt:    post.CreatedAt,
+			AuthorUID:    post.User.UID,
+			AuthorName:   post.User.Nickname,
+			LikeCount:    post.LikeCount,
+			CommentCount: post.CommentCount,
+			PictureCount: len(post.Pictures),
+		}
+		if post.Episode != nil {
+			summary := newEpisodeSummary(post.Episode)
+			view.Episode = &summary
+		}
+		result.Data = append(result.Data, view)
+	}
+
+	if result.Page, err = newPage(request, scope, postsData.LoadMoreKey); err != nil {
+		return mcp.NewToolResultErrorFromErr("生成分页游标失败", err), nil
+	}
+
+	slog.Debug("成功获取圈子帖子", "topic_id", topicID, "count", len(result.Data))
+	return newToolResult(request, result), nil
+}
@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// topListTypes maps the list_type values of get_top_list to the API categories.
+var topListTypes = map[string]string{
+	"hot_episodes":    xyzclient.TopListHotEpisodes,
+	"rising_podcasts": xyzclient.TopListRisingPodcasts,
+	"new_podcasts":    xyzclient.TopListNewPodcasts,
+	"category":        xyzclient.TopListCategory,
+}
+
+// RankedItem is one entry of the get_top_list result.
+type RankedItem struct {
+	Rank    int              `json:"rank"`
+	Trend   string           `json:"trend,omitempty"`
+	Episode *EpisodeSummary  `json:"episode,omitempty"`
+	Podcast *PodcastOverview `json:"podcast,omitempty"`
+}
+
+// TopListResult is the result of the get_top_list tool.
+type TopListResult struct {
+	ListType  string       `json:"listType"`
+	Title     string       `json:"title"`
+	UpdatedAt string       `json:"updatedAt"`
+	Items     []RankedItem `json:"items"`
+}
+
+// GetTopListHandler is the MCP handler function for the get_top_list tool.
+func GetTopListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing get_top_list tool", "arguments", request.Params.Arguments)
+
+	listType, ok := request.GetArguments()["list_type"].(string)
+	if !ok || listType == "" {
+		return mcp.NewToolResultError("错误: 输入参数 'list_type' 不能为空且必须是字符串类型。"), nil
+	}
+	category, ok := topListTypes[listType]
+	if !ok {
+		return mcp.NewToolResultError("错误: 输入参数 'list_type' 必须是 'hot_episodes'、'rising_podcasts'、'new_podcasts' 或 'category'。"), nil
+	}
+	categoryID, _ := request.GetArguments()["category_id"].(string)
+	if category == xyzclient.TopListCategory && categoryID == "" {
+		return mcp.NewToolResultError("错误: 当 'list_type' 为 'category' 时必须提供 'category_id'。"), nil
+	}
+	limit := intArg(request.GetArguments(), "limit", 20, 100)
+
+	topList, err := xyzclient.GetTopList(ctx, category, categoryID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("调用API获取榜单失败", err), nil
+	}
+
+	result := TopListResult{
+		ListType:  listType,
+		Title:     topList.Title,
+		UpdatedAt: topList.PublishDate,
+		Items:     make([]RankedItem, 0, min(limit, len(topList.Items))),
+	}
+	for i := range topList.Items {
+		if len(result.Items) >= limit {
+			break
+		}
+		entry := &topList.Items[i]
+		item := RankedItem{Rank: entry.Rank, Trend: entry.Trend}
+		if item.Rank == 0 {
+			item.Rank = i + 1
+		}
+		if entry.Episode != nil {
+			summary := newEpisodeSummary(entry.Episode)
+			item.Episode = &summary
+			podcast := newPodcastOverview(&entry.Episode.Podcast)
+			item.Podcast = &podcast
+		} else if entry.Podcast != nil {
+			podcast := newPodcastOverview(entry.Podcast)
+			item.Podcast = &podcast
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	slog.Debug("成功获取榜单", "list_type", listType, "count", len(result.Items))
+	return newToolResult(request, result), nil
+}
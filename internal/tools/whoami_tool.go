@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"xiaoyuzhoufm-mcp/internal/xyzclient"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// currentUserAlias lets the profile tools refer to the logged-in user without knowing the UID.
+const currentUserAlias = "me"
+
+// WhoamiResult is the result of the whoami tool.
+type WhoamiResult struct {
+	Token        xyzclient.TokenStatus      `json:"token"`
+	Profile      *xyzclient.UserProfileData `json:"profile,omitempty"`
+	Stats        *xyzclient.UserStatsData   `json:"stats,omitempty"`
+	ProfileError string                     `json:"profileError,omitempty"`
+	StatsError   string                     `json:"statsError,omitempty"`
+}
+
+// resolveUserID maps the "me" alias to the UID of the logged-in user.
+func resolveUserID(ctx context.Context, userID string) (string, error) {
+	if userID != currentUserAlias {
+		return userID, nil
+	}
+	tm, err := xyzclient.TokenManagerFromContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token manager: %w", err)
+	}
+	if tm.Uid == "" {
+		return "", fmt.Errorf("当前未登录，无法解析 'me'")
+	}
+	return tm.Uid, nil
+}
+
+// WhoamiHandler is the MCP handler function for the whoami tool.
+func WhoamiHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slog.Debug("Executing whoami tool", "arguments", request.Params.Arguments)
+
+	tm, err := xyzclient.TokenManagerFromContext(ctx)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("获取 Token 管理器失败", err), nil
+	}
+	if tm.Uid == "" {
+		return mcp.NewToolResultError("当前未登录，请先运行 'xiaoyuzhoufm-mcp init'。"), nil
+	}
+	var result WhoamiResult
+
+	// Profile and stats are fetched independently so that token health is reported even when the API fails.
+	if profile, err := xyzclient.GetUserProfileByID(ctx, tm.Uid); err != nil {
+		result.ProfileError = err.Error()
+	} else {
+		result.Profile = profile
+	}
+	if stats, err := xyzclient.GetUserStats(ctx, tm.Uid); err != nil {
+		result.StatsError = err.Error()
+	} else {
+		result.Stats = stats
+	}
+	// Fetching may have refreshed the token, so report the state after the calls.
+	result.Token = tm.Status()
+
+	slog.Debug("成功获取当前用户信息", "uid", tm.Uid)
+	return newToolResult(request, result), nil
+}
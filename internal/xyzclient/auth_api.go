@@ -222,3 +222,48 @@ func PerformTokenRefresh(currentRefreshToken string) (newAccessToken, newRefresh
 	slog.Debug("Token refresh successful.")
 	return newAccessToken, newRefreshToken, nil
 }
+
+// RevokeToken logs the session out server-side so that the refresh token can no longer be used.
+func RevokeToken(accessToken, refreshToken string) error {
+	apiURL := constants.APIBaseURL + "/v1/auth/logout"
+	slog.Debug("Attempting to revoke token")
+
+	if refreshToken == "" {
+		return fmt.Errorf("cannot revoke token: refresh token is empty")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, apiURL, nil) // No body
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// The caller holds the token manager's lock, so the tokens are passed in rather than
+	// going through doAuthenticatedRequest.
+	setAuthenticatedHeaders(req, accessToken)
+	req.Header.Set("x-jike-refresh-token", refreshToken)
+
+	slog.Debug("Sending HTTP request to logout API")
+	resp, err := GetHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("http request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body from logout: %w", err)
+	}
+	slog.Debug("Received response from logout API", "statusCode", resp.StatusCode, "body", string(responseBodyBytes))
+
+	// 401 means the session is already gone, which is the outcome logout wants.
+	if resp.StatusCode == http.StatusUnauthorized {
+		slog.Debug("Token already invalid on the server side.")
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("api request failed with status %d: %s", resp.StatusCode, string(responseBodyBytes))
+	}
+
+	slog.Debug("Token revoked successfully.")
+	return nil
+}
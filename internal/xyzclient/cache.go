@@ -0,0 +1,80 @@
+package xyzclient
+
+import (
+	"sync"
+	"time"
+)
+
+// maxTTLCacheEntries bounds a ttlCache, so that entries which are never read again cannot
+// pile up in a long-running server.
+const maxTTLCacheEntries = 1024
+
+// ttlCache is a small concurrency-safe cache whose entries expire at a per-entry deadline.
+type ttlCache struct {
+	mu         sync.Mutex
+	entries    map[string]ttlCacheEntry
+	maxEntries int
+}
+
+type ttlCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newTTLCache() *ttlCache {
+	return &ttlCache{entries: make(map[string]ttlCacheEntry), maxEntries: maxTTLCacheEntries}
+}
+
+// get returns the cached value for key if it has not expired yet.
+func (c *ttlCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set stores value under key until expiresAt. When the cache is full, expired entries are
+// swept first and, if none had expired, the entry closest to expiry is evicted.
+func (c *ttlCache) set(key string, value interface{}, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		c.sweep(time.Now())
+	}
+	c.entries[key] = ttlCacheEntry{value: value, expiresAt: expiresAt}
+}
+
+// sweep removes the expired entries, or the one expiring soonest if none has expired.
+// The caller must hold c.mu.
+func (c *ttlCache) sweep(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	removed := false
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+			removed = true
+			continue
+		}
+		if oldest.IsZero() || entry.expiresAt.Before(oldest) {
+			oldestKey, oldest = key, entry.expiresAt
+		}
+	}
+	if !removed && !oldest.IsZero() {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// clear removes all entries.
+func (c *ttlCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]ttlCacheEntry)
+}
@@ -0,0 +1,81 @@
+package xyzclient
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTTLCacheExpiry(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		wantHit   bool
+	}{
+		{"live entry", now.Add(time.Hour), true},
+		{"expired entry", now.Add(-time.Second), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTTLCache()
+			c.set("k", "v", tt.expiresAt)
+			value, ok := c.get("k")
+			if ok != tt.wantHit || (ok && value != "v") {
+				t.Errorf("get = %v, %v, want hit %v", value, ok, tt.wantHit)
+			}
+			if _, stored := c.entries["k"]; stored != tt.wantHit {
+				t.Errorf("entry stored after get = %v, want %v", stored, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestTTLCacheEviction(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		expiresAt   []time.Time // Deadlines of the entries k0, k1, ... filling the cache
+		wantRemoved []string
+	}{
+		{"expired entries are swept", []time.Time{now.Add(-time.Minute), now.Add(time.Hour), now.Add(-time.Second)}, []string{"k0", "k2"}},
+		{"entry closest to expiry is evicted", []time.Time{now.Add(2 * time.Hour), now.Add(time.Hour), now.Add(3 * time.Hour)}, []string{"k1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTTLCache()
+			c.maxEntries = len(tt.expiresAt)
+			for i, expiresAt := range tt.expiresAt {
+				c.entries[fmt.Sprintf("k%d", i)] = ttlCacheEntry{value: i, expiresAt: expiresAt}
+			}
+
+			c.set("new", "v", now.Add(time.Hour))
+			if _, ok := c.get("new"); !ok {
+				t.Fatal("new entry missing")
+			}
+			if want := len(tt.expiresAt) + 1 - len(tt.wantRemoved); len(c.entries) != want {
+				t.Errorf("len(entries) = %d, want %d", len(c.entries), want)
+			}
+			for _, key := range tt.wantRemoved {
+				if _, ok := c.entries[key]; ok {
+					t.Errorf("%s was not removed", key)
+				}
+			}
+		})
+	}
+}
+
+func TestTTLCacheOverwriteDoesNotEvict(t *testing.T) {
+	c := newTTLCache()
+	c.maxEntries = 2
+	expiresAt := time.Now().Add(time.Hour)
+	c.set("a", 1, expiresAt)
+	c.set("b", 2, expiresAt)
+	c.set("a", 3, expiresAt)
+	if len(c.entries) != 2 {
+		t.Errorf("len(entries) = %d, want 2", len(c.entries))
+	}
+	if value, _ := c.get("a"); value != 3 {
+		t.Errorf("get(a) = %v, want 3", value)
+	}
+}
@@ -0,0 +1,55 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+const categoryPageSize = 20
+
+// ListCategories fetches the podcast category tree.
+func ListCategories(ctx context.Context) ([]Category, error) {
+	var responseWrapper CategoryListAPIResponse
+	if err := doAuthenticatedRequest(ctx, "ListCategories", http.MethodGet, "/v1/category/list", nil, &responseWrapper); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched categories.", "count", len(responseWrapper.Data))
+	return responseWrapper.Data, nil
+}
+
+// ListCategoryPodcasts fetches a page of podcasts within a category.
+func ListCategoryPodcasts(ctx context.Context, requestData CategoryPodcastListRequest) (*CategoryPodcastListResponse, error) {
+	if requestData.CategoryID == "" {
+		return nil, fmt.Errorf("categoryID in requestData cannot be empty")
+	}
+	if requestData.Limit <= 0 {
+		requestData.Limit = categoryPageSize
+	}
+
+	var responseData CategoryPodcastListResponse
+	if err := doAuthenticatedRequest(ctx, "ListCategoryPodcasts", http.MethodPost, "/v1/category/podcast/list", requestData, &responseData); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched category podcasts.", "categoryID", requestData.CategoryID, "count", len(responseData.Data))
+	return &responseData, nil
+}
+
+// ListDiscoveryFeed fetches a page of the logged-in user's personalized discovery feed.
+func ListDiscoveryFeed(ctx context.Context, requestData DiscoveryFeedRequest) (*DiscoveryFeedResponse, error) {
+	var responseData DiscoveryFeedResponse
+	if err := doAuthenticatedRequest(ctx, "ListDiscoveryFeed", http.MethodPost, "/v1/discovery-feed/list", requestData, &responseData); err != nil {
+		return nil, err
+	}
+	for _, item := range responseData.Data {
+		if item.Episode != nil {
+			item.Episode.ComputeAccessStatus()
+		}
+	}
+
+	slog.Debug("Successfully fetched discovery feed.", "count", len(responseData.Data))
+	return &responseData, nil
+}
@@ -0,0 +1,43 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+const favoritePageSize = 20
+
+// ListFavoriteEpisodes fetches a page of the logged-in user's favorited (收藏) episodes.
+func ListFavoriteEpisodes(ctx context.Context, requestData FavoriteListRequest) (*FavoriteListResponse, error) {
+	if requestData.Limit <= 0 {
+		requestData.Limit = favoritePageSize
+	}
+
+	var responseData FavoriteListResponse
+	if err := doAuthenticatedRequest(ctx, "ListFavoriteEpisodes", http.MethodPost, "/v1/favorite/list", requestData, &responseData); err != nil {
+		return nil, err
+	}
+	for i := range responseData.Data {
+		responseData.Data[i].Episode.ComputeAccessStatus()
+	}
+
+	slog.Debug("Successfully fetched favorite episodes.", "count", len(responseData.Data))
+	return &responseData, nil
+}
+
+// SetEpisodeFavorite favorites or unfavorites an episode for the logged-in user.
+func SetEpisodeFavorite(ctx context.Context, episodeID string, favorited bool) error {
+	if episodeID == "" {
+		return fmt.Errorf("episodeID cannot be empty")
+	}
+
+	requestData := FavoriteUpdateRequest{EID: episodeID, Favorited: favorited}
+	if err := doAuthenticatedRequest(ctx, "SetEpisodeFavorite", http.MethodPost, "/v1/favorite/update", requestData, nil); err != nil {
+		return err
+	}
+
+	slog.Debug("Successfully updated episode favorite status.", "episodeID", episodeID, "favorited", favorited)
+	return nil
+}
@@ -0,0 +1,110 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	// maxInProgressScanPages bounds how many history pages ListInProgressEpisodes walks.
+	maxInProgressScanPages = 5
+	historyPageSize        = 20
+)
+
+// ListPlayedHistory fetches the logged-in user's recently played episodes, newest first.
+func ListPlayedHistory(ctx context.Context, requestData PlayedHistoryRequest) (*PlayedHistoryResponse, error) {
+	if requestData.Limit <= 0 {
+		requestData.Limit = historyPageSize
+	}
+
+	var responseData PlayedHistoryResponse
+	if err := doAuthenticatedRequest(ctx, "ListPlayedHistory", http.MethodPost, "/v1/episode-played/list-history", requestData, &responseData); err != nil {
+		return nil, err
+	}
+	for i := range responseData.Data {
+		responseData.Data[i].Episode.ComputeAccessStatus()
+	}
+
+	slog.Debug("Successfully fetched listening history.", "count", len(responseData.Data))
+	return &responseData, nil
+}
+
+// GetPlaybackProgress fetches the saved playback positions for the given episodes.
+// Episodes the user has never played are absent from the result.
+func GetPlaybackProgress(ctx context.Context, episodeIDs []string) ([]PlaybackProgress, error) {
+	if len(episodeIDs) == 0 {
+		return nil, fmt.Errorf("episodeIDs cannot be empty")
+	}
+
+	var responseWrapper PlaybackProgressAPIResponse
+	if err := doAuthenticatedRequest(ctx, "GetPlaybackProgress", http.MethodPost, "/v1/playback-progress/list", PlaybackProgressRequest{EIDs: episodeIDs}, &responseWrapper); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched playback progress.", "requested", len(episodeIDs), "count", len(responseWrapper.Data))
+	return responseWrapper.Data, nil
+}
+
+// ListInProgressEpisodes returns up to limit episodes that the user has started but not finished,
+// ordered by the time they were last played. It walks the listening history and joins it with
+// the saved playback positions, since the API has no dedicated endpoint for this list.
+func ListInProgressEpisodes(ctx context.Context, limit int) ([]InProgressEpisode, error) {
+	if limit <= 0 {
+		limit = historyPageSize
+	}
+
+	var candidates []PlayedHistoryItem
+	request := PlayedHistoryRequest{Limit: historyPageSize}
+	for page := 0; page < maxInProgressScanPages && len(candidates) < limit; page++ {
+		history, err := ListPlayedHistory(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range history.Data {
+			if !item.Episode.IsFinished {
+				candidates = append(candidates, item)
+			}
+		}
+		if history.LoadMoreKey == nil || len(history.Data) == 0 {
+			break
+		}
+		request.LoadMoreKey = history.LoadMoreKey
+	}
+	if len(candidates) == 0 {
+		return []InProgressEpisode{}, nil
+	}
+
+	eids := make([]string, 0, len(candidates))
+	for _, item := range candidates {
+		eids = append(eids, item.Episode.EID)
+	}
+	progressList, err := GetPlaybackProgress(ctx, eids)
+	if err != nil {
+		return nil, err
+	}
+	progressByEID := make(map[string]int, len(progressList))
+	for _, p := range progressList {
+		progressByEID[p.EID] = p.Progress
+	}
+
+	result := make([]InProgressEpisode, 0, limit)
+	for _, item := range candidates {
+		progress, ok := progressByEID[item.Episode.EID]
+		if !ok || progress <= 0 {
+			continue
+		}
+		result = append(result, InProgressEpisode{
+			Episode:  item.Episode,
+			PlayedAt: item.PlayedAt,
+			Progress: progress,
+		})
+		if len(result) >= limit {
+			break
+		}
+	}
+
+	slog.Debug("Successfully collected in-progress episodes.", "scanned", len(candidates), "count", len(result))
+	return result, nil
+}
@@ -1,9 +1,17 @@
 package xyzclient
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"sync"
 	"time"
+
+	"xiaoyuzhoufm-mcp/internal/constants"
 )
 
 var (
@@ -21,3 +29,93 @@ func GetHTTPClient() *http.Client {
 	})
 	return httpClient
 }
+
+// setAuthenticatedHeaders sets the headers used by the iOS app for authenticated API calls.
+func setAuthenticatedHeaders(req *http.Request, accessToken string) {
+	isoTime := time.Now().Format(time.RFC3339)
+
+	req.Header.Set("Host", "api.xiaoyuzhoufm.com")
+	req.Header.Set("User-Agent", "Xiaoyuzhou/2.57.1 (build:1576; iOS 17.4.1)")
+	req.Header.Set("Market", "AppStore")
+	req.Header.Set("App-BuildNo", "1576")
+	req.Header.Set("OS", "ios")
+	req.Header.Set("x-jike-access-token", accessToken)
+	req.Header.Set("x-jike-device-id", constants.FixedDeviceID)
+	req.Header.Set("Manufacturer", "Apple")
+	req.Header.Set("BundleID", "app.podcast.cosmos")
+	req.Header.Set("Connection", "keep-alive")
+	req.Header.Set("abtest-info", `{"old_user_discovery_feed":"enable"}`)
+	req.Header.Set("Accept-Language", "zh-Hans-CN;q=1.0")
+	req.Header.Set("Model", "iPhone14,2")
+	req.Header.Set("app-permissions", "4")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("App-Version", "2.57.1")
+	req.Header.Set("WifiConnected", "true")
+	req.Header.Set("OS-Version", "17.4.1")
+	req.Header.Set("x-custom-xiaoyuzhou-app-dev", "")
+	req.Header.Set("Local-Time", isoTime)
+	req.Header.Set("Timezone", "Asia/Shanghai")
+}
+
+// doAuthenticatedRequest sends an authenticated request to the given API path and
+// unmarshals the JSON response into out. A nil requestBody sends no body, and a nil
+// out discards the response body after checking the status code.
+func doAuthenticatedRequest(ctx context.Context, apiName, method, path string, requestBody interface{}, out interface{}) error {
+	apiURL := constants.APIBaseURL + path
+	slog.Debug("Performing authenticated API request", "api", apiName, "url", apiURL)
+
+	var bodyReader io.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body for %s: %w", apiName, err)
+		}
+		slog.Debug("Request body", "api", apiName, "body", string(requestBodyBytes))
+		bodyReader = bytes.NewBuffer(requestBodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request for %s: %w", apiName, err)
+	}
+
+	tm, err := TokenManagerFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get token manager for %s: %w", apiName, err)
+	}
+	accessToken, err := tm.GetAccessToken()
+	if err != nil {
+		return fmt.Errorf("failed to get access token for %s: %w", apiName, err)
+	}
+
+	setAuthenticatedHeaders(req, accessToken)
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	slog.Debug("Sending HTTP request", "api", apiName, "headers", req.Header)
+	resp, err := GetHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("http request failed for %s: %w", apiName, err)
+	}
+	defer resp.Body.Close()
+
+	responseBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body from %s: %w", apiName, err)
+	}
+	slog.Debug("Received response", "api", apiName, "statusCode", resp.StatusCode, "body", string(responseBodyBytes))
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API request %s failed with status %d: %s", apiName, resp.StatusCode, string(responseBodyBytes))
+	}
+
+	if out == nil || len(responseBodyBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(responseBodyBytes, out); err != nil {
+		slog.Error("Failed to unmarshal success response JSON", "api", apiName, "error", err, "responseBody", string(responseBodyBytes))
+		return fmt.Errorf("failed to unmarshal %s success response JSON: %w. Body: %s", apiName, err, string(responseBodyBytes))
+	}
+	return nil
+}
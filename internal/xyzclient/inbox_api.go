@@ -0,0 +1,28 @@
+package xyzclient
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+const inboxPageSize = 20
+
+// ListInbox fetches a page of the logged-in user's inbox: the latest episodes
+// across all subscribed podcasts in publication order.
+func ListInbox(ctx context.Context, requestData InboxListRequest) (*InboxListResponse, error) {
+	if requestData.Limit <= 0 {
+		requestData.Limit = inboxPageSize
+	}
+
+	var responseData InboxListResponse
+	if err := doAuthenticatedRequest(ctx, "ListInbox", http.MethodPost, "/v1/inbox/list", requestData, &responseData); err != nil {
+		return nil, err
+	}
+	for i := range responseData.Data {
+		responseData.Data[i].ComputeAccessStatus()
+	}
+
+	slog.Debug("Successfully fetched inbox.", "count", len(responseData.Data))
+	return &responseData, nil
+}
@@ -0,0 +1,79 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+const pickPageSize = 20
+
+// ClapEpisode claps (likes) an episode count times on behalf of the logged-in user.
+func ClapEpisode(ctx context.Context, episodeID string, count int) error {
+	if episodeID == "" {
+		return fmt.Errorf("episodeID cannot be empty")
+	}
+	if count <= 0 {
+		return fmt.Errorf("clap count must be positive, got %d", count)
+	}
+
+	if err := doAuthenticatedRequest(ctx, "ClapEpisode", http.MethodPost, "/v1/clap/create", ClapRequest{EID: episodeID, Count: count}, nil); err != nil {
+		return err
+	}
+
+	slog.Debug("Successfully clapped episode.", "episodeID", episodeID, "count", count)
+	return nil
+}
+
+// CreatePick picks (精选) an episode with a short recommendation text on the logged-in user's profile.
+func CreatePick(ctx context.Context, episodeID, pickText string) error {
+	if episodeID == "" {
+		return fmt.Errorf("episodeID cannot be empty")
+	}
+	if pickText == "" {
+		return fmt.Errorf("pickText cannot be empty")
+	}
+
+	if err := doAuthenticatedRequest(ctx, "CreatePick", http.MethodPost, "/v1/pick/create", PickCreateRequest{EID: episodeID, PickText: pickText}, nil); err != nil {
+		return err
+	}
+
+	slog.Debug("Successfully created pick.", "episodeID", episodeID)
+	return nil
+}
+
+// RemovePick removes the logged-in user's pick of an episode.
+func RemovePick(ctx context.Context, episodeID string) error {
+	if episodeID == "" {
+		return fmt.Errorf("episodeID cannot be empty")
+	}
+
+	if err := doAuthenticatedRequest(ctx, "RemovePick", http.MethodPost, "/v1/pick/remove", PickRemoveRequest{EID: episodeID}, nil); err != nil {
+		return err
+	}
+
+	slog.Debug("Successfully removed pick.", "episodeID", episodeID)
+	return nil
+}
+
+// ListUserPicks fetches a page of a user's public picks, newest first.
+func ListUserPicks(ctx context.Context, requestData PickListRequest) (*PickListResponse, error) {
+	if requestData.UID == "" {
+		return nil, fmt.Errorf("UID in requestData cannot be empty")
+	}
+	if requestData.Limit <= 0 {
+		requestData.Limit = pickPageSize
+	}
+
+	var responseData PickListResponse
+	if err := doAuthenticatedRequest(ctx, "ListUserPicks", http.MethodPost, "/v1/pick/list-history", requestData, &responseData); err != nil {
+		return nil, err
+	}
+	for i := range responseData.Data {
+		responseData.Data[i].Episode.ComputeAccessStatus()
+	}
+
+	slog.Debug("Successfully fetched user picks.", "userID", requestData.UID, "count", len(responseData.Data))
+	return &responseData, nil
+}
@@ -0,0 +1,61 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultSignedURLLifetime is assumed when the API does not report when a signed URL expires.
+	defaultSignedURLLifetime = 10 * time.Minute
+	// signedURLExpiryMargin keeps cached URLs from being handed out just before they stop working.
+	signedURLExpiryMargin = time.Minute
+)
+
+// GetPrivateMediaURL exchanges an episode's MediaKey for a signed, time-limited playable URL.
+// Results are cached per account until shortly before they expire.
+func GetPrivateMediaURL(ctx context.Context, episodeID, mediaKey string) (*PrivateMedia, error) {
+	if episodeID == "" || mediaKey == "" {
+		return nil, fmt.Errorf("episodeID and mediaKey cannot be empty")
+	}
+
+	tm, err := TokenManagerFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token manager: %w", err)
+	}
+	cache := tm.mediaCache()
+	if cached, ok := cache.get(mediaKey); ok {
+		slog.Debug("Using cached signed media URL.", "episodeID", episodeID)
+		media := cached.(PrivateMedia)
+		return &media, nil
+	}
+
+	var responseWrapper PrivateMediaAPIResponse
+	requestData := PrivateMediaRequest{EID: episodeID, MediaKey: mediaKey}
+	if err := doAuthenticatedRequest(ctx, "GetPrivateMediaURL", http.MethodPost, "/v1/private-media/get", requestData, &responseWrapper); err != nil {
+		return nil, err
+	}
+	media := responseWrapper.Data
+	if media.URL == "" {
+		return nil, fmt.Errorf("private media response for episode %s contains no URL", episodeID)
+	}
+
+	expiresAt := time.Now().Add(defaultSignedURLLifetime)
+	if media.ExpireAt != "" {
+		if parsed, err := time.Parse(time.RFC3339, media.ExpireAt); err == nil {
+			expiresAt = parsed
+		} else {
+			slog.Warn("Failed to parse signed media URL expiry, using default lifetime.", "expireAt", media.ExpireAt, "error", err)
+		}
+	}
+	media.ExpireAt = expiresAt.Format(time.RFC3339)
+	if cacheUntil := expiresAt.Add(-signedURLExpiryMargin); time.Now().Before(cacheUntil) {
+		cache.set(mediaKey, media, cacheUntil)
+	}
+
+	slog.Debug("Successfully fetched signed media URL.", "episodeID", episodeID, "expireAt", media.ExpireAt)
+	return &media, nil
+}
@@ -2,30 +2,32 @@ package xyzclient
 
 import (
 	"bytes" // Added bytes import
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 
 	"xiaoyuzhoufm-mcp/internal/constants"
 )
 
 // GetPodcastDetailsByID fetches detailed information for a specific podcast by its PID.
-func GetPodcastDetailsByID(podcastID string) (*PodcastDetailData, error) {
+func GetPodcastDetailsByID(ctx context.Context, podcastID string) (*PodcastDetailData, error) {
 	if podcastID == "" {
 		return nil, fmt.Errorf("podcastID cannot be empty")
 	}
 	apiURL := fmt.Sprintf("%s/v1/podcast/get?pid=%s", constants.APIBaseURL, podcastID)
 	slog.Debug("Fetching podcast details by ID", "url", apiURL, "podcastID", podcastID)
 
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for GetPodcastDetailsByID: %w", err)
 	}
 
-	tm, err := GetTokenManager()
+	tm, err := TokenManagerFromContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token manager: %w", err)
 	}
@@ -88,7 +90,7 @@ func GetPodcastDetailsByID(podcastID string) (*PodcastDetailData, error) {
 }
 
 // ListPodcastEpisodes fetches a list of episodes for a specific podcast.
-func ListPodcastEpisodes(requestData EpisodeListRequest) (*EpisodeListResponseData, error) {
+func ListPodcastEpisodes(ctx context.Context, requestData EpisodeListRequest) (*EpisodeListResponseData, error) {
 	if requestData.PID == "" {
 		return nil, fmt.Errorf("podcastID (PID) in requestData cannot be empty")
 	}
@@ -102,12 +104,12 @@ func ListPodcastEpisodes(requestData EpisodeListRequest) (*EpisodeListResponseDa
 	}
 	slog.Debug("ListPodcastEpisodes request body", "body", string(requestBodyBytes))
 
-	req, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewBuffer(requestBodyBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewBuffer(requestBodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for ListPodcastEpisodes: %w", err)
 	}
 
-	tm, err := GetTokenManager()
+	tm, err := TokenManagerFromContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token manager: %w", err)
 	}
@@ -166,24 +168,28 @@ func ListPodcastEpisodes(requestData EpisodeListRequest) (*EpisodeListResponseDa
 		return nil, fmt.Errorf("failed to unmarshal ListPodcastEpisodes success response JSON: %w. Body: %s", err, string(responseBodyBytes))
 	}
 
+	for i := range responseData.Data {
+		responseData.Data[i].ComputeAccessStatus()
+	}
+
 	slog.Debug("Successfully fetched and parsed podcast episodes list.", "podcastID", requestData.PID, "count", len(responseData.Data)) // Changed to responseData.Data
 	return &responseData, nil                                                                                                           // Changed to return &responseData
 }
 
 // GetEpisodeDetailsByID fetches detailed information for a specific episode by its EID.
-func GetEpisodeDetailsByID(episodeID string) (*Episode, error) {
+func GetEpisodeDetailsByID(ctx context.Context, episodeID string) (*Episode, error) {
 	if episodeID == "" {
 		return nil, fmt.Errorf("episodeID cannot be empty")
 	}
 	apiURL := fmt.Sprintf("%s/v1/episode/get?eid=%s", constants.APIBaseURL, episodeID)
 	slog.Debug("Fetching episode details by ID", "url", apiURL, "episodeID", episodeID)
 
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for GetEpisodeDetailsByID: %w", err)
 	}
 
-	tm, err := GetTokenManager()
+	tm, err := TokenManagerFromContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token manager: %w", err)
 	}
@@ -241,6 +247,44 @@ func GetEpisodeDetailsByID(episodeID string) (*Episode, error) {
 		return nil, fmt.Errorf("failed to unmarshal GetEpisodeDetailsByID success response JSON: %w. Body: %s", err, string(responseBodyBytes))
 	}
 
+	responseWrapper.Data.ComputeAccessStatus()
+
 	slog.Debug("Successfully fetched and parsed episode details.", "episodeID", episodeID, "title", responseWrapper.Data.Title)
 	return &responseWrapper.Data, nil
 }
+
+// ListPopularEpisodes fetches a podcast's most popular episodes as ranked by the platform.
+// Only podcasts with HasPopularEpisodes set return a non-empty list.
+func ListPopularEpisodes(ctx context.Context, podcastID string) ([]Episode, error) {
+	if podcastID == "" {
+		return nil, fmt.Errorf("podcastID cannot be empty")
+	}
+
+	var responseWrapper PopularEpisodesAPIResponse
+	path := "/v1/episode/list-popular?pid=" + url.QueryEscape(podcastID)
+	if err := doAuthenticatedRequest(ctx, "ListPopularEpisodes", http.MethodGet, path, nil, &responseWrapper); err != nil {
+		return nil, err
+	}
+	for i := range responseWrapper.Data {
+		responseWrapper.Data[i].ComputeAccessStatus()
+	}
+
+	slog.Debug("Successfully fetched popular episodes.", "podcastID", podcastID, "count", len(responseWrapper.Data))
+	return responseWrapper.Data, nil
+}
+
+// GetRelatedPodcasts fetches the platform's "similar podcasts" recommendations for a podcast.
+func GetRelatedPodcasts(ctx context.Context, podcastID string) ([]PodcastSummary, error) {
+	if podcastID == "" {
+		return nil, fmt.Errorf("podcastID cannot be empty")
+	}
+
+	var responseWrapper RelatedPodcastsAPIResponse
+	path := "/v1/podcast/related?pid=" + url.QueryEscape(podcastID)
+	if err := doAuthenticatedRequest(ctx, "GetRelatedPodcasts", http.MethodGet, path, nil, &responseWrapper); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched related podcasts.", "podcastID", podcastID, "count", len(responseWrapper.Data))
+	return responseWrapper.Data, nil
+}
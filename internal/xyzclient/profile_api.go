@@ -1,6 +1,7 @@
 package xyzclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,19 +13,19 @@ import (
 )
 
 // GetUserProfileByID fetches a user's public profile information by their UID.
-func GetUserProfileByID(userID string) (*UserProfileData, error) {
+func GetUserProfileByID(ctx context.Context, userID string) (*UserProfileData, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("userID cannot be empty")
 	}
 	apiURL := fmt.Sprintf("%s/v1/profile/get?uid=%s", constants.APIBaseURL, userID)
 	slog.Debug("Fetching user profile by ID", "url", apiURL, "userID", userID)
 
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for GetUserProfileByID: %w", err)
 	}
 
-	tm, err := GetTokenManager()
+	tm, err := TokenManagerFromContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token manager: %w", err)
 	}
@@ -90,19 +91,19 @@ func GetUserProfileByID(userID string) (*UserProfileData, error) {
 }
 
 // GetUserStats fetches a user's statistics by their UID.
-func GetUserStats(userID string) (*UserStatsData, error) {
+func GetUserStats(ctx context.Context, userID string) (*UserStatsData, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("userID cannot be empty for GetUserStats")
 	}
 	apiURL := fmt.Sprintf("%s/v1/user-stats/get?uid=%s", constants.APIBaseURL, userID)
 	slog.Debug("Fetching user stats by ID", "url", apiURL, "userID", userID)
 
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for GetUserStats: %w", err)
 	}
 
-	tm, err := GetTokenManager()
+	tm, err := TokenManagerFromContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token manager for GetUserStats: %w", err)
 	}
@@ -0,0 +1,48 @@
+package xyzclient
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+const purchasedPageSize = 20
+
+// IsPaid reports whether the episode is paid content.
+func (e *Episode) IsPaid() bool {
+	return e.PayType != "" && e.PayType != PayTypeFree
+}
+
+// ComputeAccessStatus derives whether the logged-in user can play the episode from
+// PayType and IsPurchased, stores it in AccessStatus and returns it.
+func (e *Episode) ComputeAccessStatus() AccessStatus {
+	switch {
+	case !e.IsPaid():
+		e.AccessStatus = AccessFree
+	case e.IsPurchased:
+		e.AccessStatus = AccessPurchased
+	default:
+		e.AccessStatus = AccessLocked
+	}
+	return e.AccessStatus
+}
+
+// ListPurchasedContent fetches a page of the paid podcasts and episodes the logged-in user has bought.
+func ListPurchasedContent(ctx context.Context, requestData PurchasedListRequest) (*PurchasedListResponse, error) {
+	if requestData.Limit <= 0 {
+		requestData.Limit = purchasedPageSize
+	}
+
+	var responseData PurchasedListResponse
+	if err := doAuthenticatedRequest(ctx, "ListPurchasedContent", http.MethodPost, "/v1/purchase/list", requestData, &responseData); err != nil {
+		return nil, err
+	}
+	for _, item := range responseData.Data {
+		if item.Episode != nil {
+			item.Episode.ComputeAccessStatus()
+		}
+	}
+
+	slog.Debug("Successfully fetched purchased content.", "count", len(responseData.Data))
+	return &responseData, nil
+}
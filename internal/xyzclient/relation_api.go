@@ -0,0 +1,55 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+const relationPageSize = 20
+
+// ListFollowers fetches a page of users who follow the given user.
+func ListFollowers(ctx context.Context, requestData RelationListRequest) (*RelationListResponse, error) {
+	return listRelations(ctx, "ListFollowers", "/v1/user-relation/list-follower", requestData)
+}
+
+// ListFollowing fetches a page of users the given user follows.
+func ListFollowing(ctx context.Context, requestData RelationListRequest) (*RelationListResponse, error) {
+	return listRelations(ctx, "ListFollowing", "/v1/user-relation/list-following", requestData)
+}
+
+func listRelations(ctx context.Context, apiName, path string, requestData RelationListRequest) (*RelationListResponse, error) {
+	if requestData.UID == "" {
+		return nil, fmt.Errorf("UID in requestData cannot be empty for %s", apiName)
+	}
+	if requestData.Limit <= 0 {
+		requestData.Limit = relationPageSize
+	}
+
+	var responseData RelationListResponse
+	if err := doAuthenticatedRequest(ctx, apiName, http.MethodPost, path, requestData, &responseData); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched user relations.", "api", apiName, "userID", requestData.UID, "count", len(responseData.Data))
+	return &responseData, nil
+}
+
+// UpdateUserRelation follows or unfollows a user on behalf of the logged-in user.
+func UpdateUserRelation(ctx context.Context, userID string, follow bool) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty")
+	}
+
+	requestData := RelationUpdateRequest{UID: userID, Action: RelationActionUnfollow}
+	if follow {
+		requestData.Action = RelationActionFollow
+	}
+	if err := doAuthenticatedRequest(ctx, "UpdateUserRelation", http.MethodPost, "/v1/user-relation/update", requestData, nil); err != nil {
+		return err
+	}
+
+	slog.Debug("Successfully updated user relation.", "userID", userID, "action", requestData.Action)
+	return nil
+}
@@ -2,6 +2,7 @@ package xyzclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,7 +15,7 @@ import (
 
 // doSearch is a generic helper function to perform search requests.
 // It returns the raw 'data' part of the response, highlight word, and load more key.
-func doSearch(requestData SearchRequest) (json.RawMessage, *HighlightWord, *SearchAPILoadMoreKey, error) {
+func doSearch(ctx context.Context, requestData SearchRequest) (json.RawMessage, *HighlightWord, *SearchAPILoadMoreKey, error) {
 	apiURL := fmt.Sprintf("%s/v1/search/create", constants.APIBaseURL)
 	slog.Debug("Performing search request", "url", apiURL, "type", requestData.Type, "keyword", requestData.Keyword)
 
@@ -24,12 +25,12 @@ func doSearch(requestData SearchRequest) (json.RawMessage, *HighlightWord, *Sear
 	}
 	slog.Debug("Search request body", "body", string(requestBodyBytes))
 
-	req, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewBuffer(requestBodyBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewBuffer(requestBodyBytes))
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create request for search: %w", err)
 	}
 
-	tm, err := GetTokenManager()
+	tm, err := TokenManagerFromContext(ctx)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to get token manager for search: %w", err)
 	}
@@ -98,13 +99,13 @@ func doSearch(requestData SearchRequest) (json.RawMessage, *HighlightWord, *Sear
 }
 
 // SearchPodcasts searches for podcasts.
-func SearchPodcasts(keyword string, loadMoreKey *SearchAPILoadMoreKey) (*PodcastSearchResponse, error) {
+func SearchPodcasts(ctx context.Context, keyword string, loadMoreKey *SearchAPILoadMoreKey) (*PodcastSearchResponse, error) {
 	request := SearchRequest{
 		Keyword:     keyword,
 		Type:        "PODCAST",
 		LoadMoreKey: loadMoreKey,
 	}
-	rawData, highlight, lmk, err := doSearch(request)
+	rawData, highlight, lmk, err := doSearch(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -123,14 +124,14 @@ func SearchPodcasts(keyword string, loadMoreKey *SearchAPILoadMoreKey) (*Podcast
 }
 
 // SearchEpisodes searches for episodes.
-func SearchEpisodes(keyword string, pid string, loadMoreKey *SearchAPILoadMoreKey) (*EpisodeSearchResponse, error) {
+func SearchEpisodes(ctx context.Context, keyword string, pid string, loadMoreKey *SearchAPILoadMoreKey) (*EpisodeSearchResponse, error) {
 	request := SearchRequest{
 		Keyword:     keyword,
 		Type:        "EPISODE",
 		PID:         pid,
 		LoadMoreKey: loadMoreKey,
 	}
-	rawData, highlight, lmk, err := doSearch(request)
+	rawData, highlight, lmk, err := doSearch(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +141,9 @@ func SearchEpisodes(keyword string, pid string, loadMoreKey *SearchAPILoadMoreKe
 		slog.Error("Failed to unmarshal episode search data", "error", err, "rawData", string(rawData))
 		return nil, fmt.Errorf("failed to unmarshal episode search data: %w", err)
 	}
+	for i := range episodes {
+		(*Episode)(&episodes[i]).ComputeAccessStatus()
+	}
 
 	return &EpisodeSearchResponse{
 		Data:          episodes,
@@ -149,13 +153,13 @@ func SearchEpisodes(keyword string, pid string, loadMoreKey *SearchAPILoadMoreKe
 }
 
 // SearchUsers searches for users.
-func SearchUsers(keyword string, loadMoreKey *SearchAPILoadMoreKey) (*UserSearchResponse, error) {
+func SearchUsers(ctx context.Context, keyword string, loadMoreKey *SearchAPILoadMoreKey) (*UserSearchResponse, error) {
 	request := SearchRequest{
 		Keyword:     keyword,
 		Type:        "USER",
 		LoadMoreKey: loadMoreKey,
 	}
-	rawData, highlight, lmk, err := doSearch(request)
+	rawData, highlight, lmk, err := doSearch(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -172,3 +176,40 @@ func SearchUsers(keyword string, loadMoreKey *SearchAPILoadMoreKey) (*UserSearch
 		LoadMoreKey:   lmk,
 	}, nil
 }
+
+// GetSearchSuggestions fetches autocomplete suggestions for a partial keyword.
+func GetSearchSuggestions(ctx context.Context, keyword string) ([]SearchSuggestion, error) {
+	if keyword == "" {
+		return nil, fmt.Errorf("keyword cannot be empty")
+	}
+
+	var responseWrapper SearchSuggestionAPIResponse
+	if err := doAuthenticatedRequest(ctx, "GetSearchSuggestions", http.MethodPost, "/v1/search/get-suggestion", SearchSuggestionRequest{Keyword: keyword}, &responseWrapper); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched search suggestions.", "keyword", keyword, "count", len(responseWrapper.Data))
+	return responseWrapper.Data, nil
+}
+
+// GetHotSearches fetches the platform's trending search terms.
+func GetHotSearches(ctx context.Context) ([]HotSearchWord, error) {
+	var responseWrapper HotSearchAPIResponse
+	if err := doAuthenticatedRequest(ctx, "GetHotSearches", http.MethodGet, "/v1/search/list-hot-words", nil, &responseWrapper); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched hot searches.", "count", len(responseWrapper.Data))
+	return responseWrapper.Data, nil
+}
+
+// GetSearchPresets fetches the preset queries the app suggests before the user types anything.
+func GetSearchPresets(ctx context.Context) ([]SearchPreset, error) {
+	var responseWrapper SearchPresetAPIResponse
+	if err := doAuthenticatedRequest(ctx, "GetSearchPresets", http.MethodGet, "/v1/search/get-preset", nil, &responseWrapper); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched search presets.", "count", len(responseWrapper.Data))
+	return responseWrapper.Data, nil
+}
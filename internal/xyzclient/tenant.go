@@ -0,0 +1,143 @@
+package xyzclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// ErrNotLoggedIn is returned when a tenant has no saved Xiaoyuzhou token yet.
+var ErrNotLoggedIn = errors.New("not logged in to Xiaoyuzhou")
+
+type tokenManagerContextKey struct{}
+
+// WithTokenManager returns a copy of ctx whose API calls act as the account held by tm.
+func WithTokenManager(ctx context.Context, tm *TokenManager) context.Context {
+	return context.WithValue(ctx, tokenManagerContextKey{}, tm)
+}
+
+// TokenManagerFromContext returns the TokenManager stored in ctx, falling back to the
+// process-wide instance used in single-account mode.
+func TokenManagerFromContext(ctx context.Context) (*TokenManager, error) {
+	if tm, ok := ctx.Value(tokenManagerContextKey{}).(*TokenManager); ok && tm != nil {
+		return tm, nil
+	}
+	return GetTokenManager()
+}
+
+// tenantDirPattern matches principal names that are safe to use verbatim as directory names.
+var tenantDirPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// TenantStore keeps one TokenManager per principal, each backed by its own token file
+// under dir/<principal>/token.json. Because caches live on the TokenManager, every
+// account's cached data stays isolated from the others.
+type TenantStore struct {
+	dir string
+
+	mu       sync.Mutex
+	managers map[string]*TokenManager
+}
+
+// NewTenantStore creates a store rooted at dir.
+func NewTenantStore(dir string) *TenantStore {
+	return &TenantStore{dir: dir, managers: make(map[string]*TokenManager)}
+}
+
+// GetDefaultTenantsDir returns the default directory holding per-principal token files.
+// Path is typically ~/.mcp/xiaoyuzhoufm-mcp/tenants
+func GetDefaultTenantsDir() (string, error) {
+	userTokenPath, err := GetUserTokenPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(userTokenPath), "tenants"), nil
+}
+
+// TokenPath returns the token file path of principal. Names that are not plain
+// identifiers are hashed so that they cannot escape the tenants directory.
+func (ts *TenantStore) TokenPath(principal string) string {
+	dirName := principal
+	if !tenantDirPattern.MatchString(principal) || principal == "." || principal == ".." {
+		sum := sha256.Sum256([]byte(principal))
+		dirName = "p-" + hex.EncodeToString(sum[:8])
+	}
+	return filepath.Join(ts.dir, dirName, tokenFileName)
+}
+
+// TokenManager returns the logged-in TokenManager of principal, loading its token file on
+// first use. ErrNotLoggedIn is returned when the principal has not logged in yet.
+func (ts *TenantStore) TokenManager(principal string) (*TokenManager, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if tm, ok := ts.managers[principal]; ok {
+		return tm, nil
+	}
+
+	tokenPath := ts.TokenPath(principal)
+	tm := &TokenManager{}
+	if err := tm.LoadTokenFromPath(tokenPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotLoggedIn
+		}
+		return nil, fmt.Errorf("failed to load token of principal %s: %w", principal, err)
+	}
+	ts.managers[principal] = tm
+	slog.Debug("Loaded tenant token.", "principal", principal, "uid", tm.Uid, "path", tokenPath)
+	return tm, nil
+}
+
+// Login stores a freshly obtained token for principal, replacing any previous account.
+func (ts *TenantStore) Login(principal, accessToken, refreshToken, uid, nickname string) error {
+	tm := &TokenManager{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Uid:          uid,
+		Nickname:     nickname,
+	}
+	tokenPath := ts.TokenPath(principal)
+	if err := os.MkdirAll(filepath.Dir(tokenPath), 0o700); err != nil {
+		return fmt.Errorf("failed to create tenant directory for %s: %w", principal, err)
+	}
+	if err := tm.SaveTokenToPath(tokenPath); err != nil {
+		return err
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if previous, ok := ts.managers[principal]; ok {
+		previous.ClearCaches()
+	}
+	ts.managers[principal] = tm
+	slog.Info("Tenant logged in.", "principal", principal, "uid", uid)
+	return nil
+}
+
+// Logout revokes and wipes the token of principal and forgets its TokenManager.
+// The manager stays registered until the token file is wiped, so that concurrent
+// requests of the principal cannot load the token again in the meantime.
+func (ts *TenantStore) Logout(principal string) error {
+	tm, err := ts.TokenManager(principal)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Tenant logging out.", "principal", principal, "uid", tm.Uid)
+	logoutErr := tm.Logout(ts.TokenPath(principal))
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	// A concurrent Login may already have replaced the manager.
+	if ts.managers[principal] == tm {
+		delete(ts.managers, principal)
+	}
+	return logoutErr
+}
@@ -0,0 +1,44 @@
+package xyzclient
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestTenantStoreLogout(t *testing.T) {
+	ts := NewTenantStore(t.TempDir())
+	// Without a refresh token Logout skips the server-side revocation.
+	if err := ts.Login("alice", "access", "", "u1", "Alice"); err != nil {
+		t.Fatal(err)
+	}
+	tm, err := ts.TokenManager("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Hold the manager's lock so that Logout stops before wiping the token file.
+	tm.mu.Lock()
+	done := make(chan error)
+	go func() { done <- ts.Logout("alice") }()
+
+	// Until the wipe finishes, requests must get the manager being logged out rather than
+	// a new one loaded from the token file.
+	if got, err := ts.TokenManager("alice"); err != nil || got != tm {
+		t.Errorf("TokenManager during logout = %p, %v, want the existing manager %p", got, err, tm)
+	}
+	tm.mu.Unlock()
+	if err := <-done; err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+
+	if _, err := os.Stat(ts.TokenPath("alice")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("token file still exists: %v", err)
+	}
+	if _, err := ts.TokenManager("alice"); !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("TokenManager after logout = %v, want ErrNotLoggedIn", err)
+	}
+	if err := ts.Logout("alice"); !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("second Logout = %v, want ErrNotLoggedIn", err)
+	}
+}
@@ -14,6 +14,8 @@ import (
 
 const (
 	tokenFileName = "token.json"
+	// accessTokenLifetime is how long an access token is trusted before it is refreshed.
+	accessTokenLifetime = 20 * time.Minute
 )
 
 type TokenManager struct {
@@ -23,6 +25,10 @@ type TokenManager struct {
 	Nickname             string `json:"nickname"`
 	LastUpdatedTimestamp int64  `json:"last_updated_timestamp,omitempty"`
 	loadedTokenPath      string `json:"-"` // Path from which token was loaded or to which it was last saved. Not persisted in JSON.
+
+	mu            sync.Mutex // Serializes refreshes when several sessions share this account
+	cacheOnce     sync.Once
+	mediaURLCache *ttlCache // Signed media URLs are only valid for this account, so the cache lives here.
 }
 
 var (
@@ -31,6 +37,10 @@ var (
 	initErr          error
 )
 
+// ErrRevokeFailed is returned by Logout when the local token was wiped but the session
+// could not be revoked server-side.
+var ErrRevokeFailed = errors.New("server-side revocation failed")
+
 // GetUserTokenPath returns the OS-specific path for storing the token in the user's home directory.
 // Path is typically ~/.mcp/xiaoyuzhoufm-mcp/token.json
 func GetUserTokenPath() (string, error) {
@@ -105,12 +115,15 @@ func (tm *TokenManager) SaveTokenToPath(tokenPath string) error {
 }
 
 func (tm *TokenManager) GetAccessToken() (string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
 	if tm.AccessToken == "" {
 		slog.Warn("GetAccessToken called but access token is empty (initial state or previous error).")
 		return "", fmt.Errorf("not authenticated: access token is empty")
 	}
 
-	const tokenTimeoutSeconds = 20 * 60 // 20 minutes
+	tokenTimeoutSeconds := int64(accessTokenLifetime.Seconds())
 	currentTime := time.Now().Unix()
 
 	if tm.LastUpdatedTimestamp > 0 && (currentTime-tm.LastUpdatedTimestamp > tokenTimeoutSeconds) {
@@ -159,3 +172,113 @@ func (tm *TokenManager) RefreshAccessToken() error {
 	slog.Debug("Refreshed token saved successfully.", "path", tm.loadedTokenPath)
 	return nil
 }
+
+// TokenStatus describes the health of the token held by a TokenManager. It is returned to
+// MCP clients, so it carries no secrets or server paths.
+type TokenStatus struct {
+	Uid             string     `json:"uid"`
+	Nickname        string     `json:"nickname"`
+	HasAccessToken  bool       `json:"hasAccessToken"`
+	HasRefreshToken bool       `json:"hasRefreshToken"`
+	LastRefreshedAt *time.Time `json:"lastRefreshedAt,omitempty"`
+	ExpectedExpiry  *time.Time `json:"expectedExpiry,omitempty"` // After this the access token is refreshed on next use
+	Expired         bool       `json:"expired"`
+}
+
+// Status reports the current token state without refreshing it.
+func (tm *TokenManager) Status() TokenStatus {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	status := TokenStatus{
+		Uid:             tm.Uid,
+		Nickname:        tm.Nickname,
+		HasAccessToken:  tm.AccessToken != "",
+		HasRefreshToken: tm.RefreshToken != "",
+	}
+	if tm.LastUpdatedTimestamp > 0 {
+		lastRefreshedAt := time.Unix(tm.LastUpdatedTimestamp, 0)
+		expectedExpiry := lastRefreshedAt.Add(accessTokenLifetime)
+		status.LastRefreshedAt = &lastRefreshedAt
+		status.ExpectedExpiry = &expectedExpiry
+		status.Expired = time.Now().After(expectedExpiry)
+	}
+	return status
+}
+
+// Logout revokes the session server-side, securely wipes the token file at tokenPath
+// and clears the in-memory token and caches. Local cleanup happens even when revocation
+// fails, in which case the revocation error is returned after wiping.
+func (tm *TokenManager) Logout(tokenPath string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	var revokeErr error
+	if tm.RefreshToken != "" {
+		revokeErr = RevokeToken(tm.AccessToken, tm.RefreshToken)
+		if revokeErr != nil {
+			slog.Warn("Failed to revoke token server-side, wiping local token anyway.", "error", revokeErr)
+		}
+	}
+
+	if err := wipeFile(tokenPath); err != nil {
+		return fmt.Errorf("failed to wipe token file %s: %w", tokenPath, err)
+	}
+
+	tm.ClearCaches()
+	tm.AccessToken = ""
+	tm.RefreshToken = ""
+	tm.Uid = ""
+	tm.Nickname = ""
+	tm.LastUpdatedTimestamp = 0
+	tm.loadedTokenPath = ""
+	slog.Debug("Logged out and local token wiped.", "path", tokenPath)
+
+	if revokeErr != nil {
+		return fmt.Errorf("local token wiped, but %w: %w", ErrRevokeFailed, revokeErr)
+	}
+	return nil
+}
+
+// wipeFile overwrites a file with zeros and syncs it to disk before removing it,
+// so that the token does not linger in the file's old blocks. A missing file is not an error.
+func wipeFile(path string) error {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(make([]byte, info.Size())); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// mediaCache returns the account's signed media URL cache, creating it on first use.
+func (tm *TokenManager) mediaCache() *ttlCache {
+	tm.cacheOnce.Do(func() {
+		tm.mediaURLCache = newTTLCache()
+	})
+	return tm.mediaURLCache
+}
+
+// ClearCaches drops all cached data tied to this account.
+func (tm *TokenManager) ClearCaches() {
+	tm.mediaCache().clear()
+	slog.Debug("Account caches cleared.", "uid", tm.Uid)
+}
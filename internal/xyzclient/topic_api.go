@@ -0,0 +1,60 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+const topicPostPageSize = 20
+
+// GetTopic fetches a topic (圈子) by its ID, as found in Episode.TopicID.
+func GetTopic(ctx context.Context, topicID string) (*Topic, error) {
+	if topicID == "" {
+		return nil, fmt.Errorf("topicID cannot be empty")
+	}
+	return getTopic(ctx, "GetTopic", "/v1/topic/get?id="+url.QueryEscape(topicID))
+}
+
+// GetPodcastTopic fetches the topic attached to a podcast that has HasTopic set.
+func GetPodcastTopic(ctx context.Context, podcastID string) (*Topic, error) {
+	if podcastID == "" {
+		return nil, fmt.Errorf("podcastID cannot be empty")
+	}
+	return getTopic(ctx, "GetPodcastTopic", "/v1/topic/get?pid="+url.QueryEscape(podcastID))
+}
+
+func getTopic(ctx context.Context, apiName, path string) (*Topic, error) {
+	var responseWrapper TopicAPIResponse
+	if err := doAuthenticatedRequest(ctx, apiName, http.MethodGet, path, nil, &responseWrapper); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Successfully fetched topic.", "api", apiName, "topicID", responseWrapper.Data.ID, "name", responseWrapper.Data.Name)
+	return &responseWrapper.Data, nil
+}
+
+// ListTopicPosts fetches a page of posts in a topic's feed, newest first.
+func ListTopicPosts(ctx context.Context, requestData TopicPostListRequest) (*TopicPostListResponse, error) {
+	if requestData.TopicID == "" {
+		return nil, fmt.Errorf("topicID in requestData cannot be empty")
+	}
+	if requestData.Limit <= 0 {
+		requestData.Limit = topicPostPageSize
+	}
+
+	var responseData TopicPostListResponse
+	if err := doAuthenticatedRequest(ctx, "ListTopicPosts", http.MethodPost, "/v1/topic/list-posts", requestData, &responseData); err != nil {
+		return nil, err
+	}
+	for _, post := range responseData.Data {
+		if post.Episode != nil {
+			post.Episode.ComputeAccessStatus()
+		}
+	}
+
+	slog.Debug("Successfully fetched topic posts.", "topicID", requestData.TopicID, "count", len(responseData.Data))
+	return &responseData, nil
+}
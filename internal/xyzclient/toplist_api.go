@@ -0,0 +1,37 @@
+package xyzclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// GetTopList fetches one of the platform's ranking lists. categoryID is only used,
+// and then required, for TopListCategory.
+func GetTopList(ctx context.Context, category, categoryID string) (*TopListData, error) {
+	switch category {
+	case TopListHotEpisodes, TopListRisingPodcasts, TopListNewPodcasts:
+		categoryID = ""
+	case TopListCategory:
+		if categoryID == "" {
+			return nil, fmt.Errorf("categoryID cannot be empty for %s", TopListCategory)
+		}
+	default:
+		return nil, fmt.Errorf("unknown top list category: %s", category)
+	}
+
+	var responseWrapper TopListAPIResponse
+	requestData := TopListRequest{Category: category, CategoryID: categoryID}
+	if err := doAuthenticatedRequest(ctx, "GetTopList", http.MethodPost, "/v1/top-list/get", requestData, &responseWrapper); err != nil {
+		return nil, err
+	}
+	for _, entry := range responseWrapper.Data.Items {
+		if entry.Episode != nil {
+			entry.Episode.ComputeAccessStatus()
+		}
+	}
+
+	slog.Debug("Successfully fetched top list.", "category", category, "categoryID", categoryID, "count", len(responseWrapper.Data.Items))
+	return &responseWrapper.Data, nil
+}
@@ -242,6 +242,11 @@ type PodcastDetailAPIResponse struct {
 	Data PodcastDetailData `json:"data"`
 }
 
+// RelatedPodcastsAPIResponse wraps the related podcast recommendations as per the API's structure.
+type RelatedPodcastsAPIResponse struct {
+	Data []PodcastSummary `json:"data"`
+}
+
 // LoadMoreKey defines the structure for pagination keys.
 type LoadMoreKey struct {
 	Direction string `json:"direction,omitempty"`
@@ -319,6 +324,18 @@ type TranscriptInfo struct {
 	MediaID string `json:"mediaId"`
 }
 
+// PayTypeFree is the PayType of free podcasts and episodes. Any other non-empty value denotes paid content.
+const PayTypeFree = "FREE"
+
+// AccessStatus describes whether the logged-in user can play an episode.
+type AccessStatus string
+
+const (
+	AccessFree      AccessStatus = "FREE"      // Free content
+	AccessPurchased AccessStatus = "PURCHASED" // Paid content the user has bought
+	AccessLocked    AccessStatus = "LOCKED"    // Paid content the user has not bought; media URLs will not play
+)
+
 // Episode defines the structure for a single podcast episode.
 type Episode struct {
 	Type           string              `json:"type"`
@@ -345,7 +362,9 @@ type Episode struct {
 	IsFavorited    bool                `json:"isFavorited"`
 	IsPicked       bool                `json:"isPicked"`
 	Permissions    []PodcastPermission `json:"permissions"` // Reusing PodcastPermission
-	PayType        string              `json:"payType"`
+	PayType        string              `json:"payType"`     // e.g., "FREE", "PAY_EPISODE"
+	IsPurchased    bool                `json:"isPurchased"`
+	AccessStatus   AccessStatus        `json:"accessStatus,omitempty"` // Computed by this client, see Episode.ComputeAccessStatus
 	WechatShare    WechatShareInfo     `json:"wechatShare"`
 	Labels         []interface{}       `json:"labels"`
 	Sponsors       []interface{}       `json:"sponsors"`
@@ -369,6 +388,11 @@ type EpisodeDetailAPIResponse struct {
 	Data Episode `json:"data"`
 }
 
+// PopularEpisodesAPIResponse wraps a podcast's popular episodes as per the API's structure.
+type PopularEpisodesAPIResponse struct {
+	Data []Episode `json:"data"`
+}
+
 // EpisodeListAPIResponse has been removed as EpisodeListResponseData is the top-level structure.
 
 // --- Search Related Types ---
@@ -440,3 +464,392 @@ type UserSearchResponse struct {
 	HighlightWord *HighlightWord         `json:"highlightWord,omitempty"`
 	LoadMoreKey   *SearchAPILoadMoreKey  `json:"loadMoreKey,omitempty"`
 }
+
+// --- Listening History Related Types ---
+
+// PlayedHistoryRequest defines the request body for listing the user's listening history.
+type PlayedHistoryRequest struct {
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// PlayedHistoryItem represents an episode in the user's listening history.
+type PlayedHistoryItem struct {
+	Type     string  `json:"type"`     // e.g., "EPISODE_PLAYED"
+	PlayedAt string  `json:"playedAt"` // ISO Date string of the last play
+	Episode  Episode `json:"episode"`
+}
+
+// PlayedHistoryResponse defines the API response structure for the listening history.
+type PlayedHistoryResponse struct {
+	Data        []PlayedHistoryItem `json:"data"`
+	LoadMoreKey interface{}         `json:"loadMoreKey,omitempty"`
+}
+
+// PlaybackProgressRequest defines the request body for fetching playback progress.
+type PlaybackProgressRequest struct {
+	EIDs []string `json:"eids"`
+}
+
+// PlaybackProgress represents the user's saved playback position for an episode.
+type PlaybackProgress struct {
+	EID       string `json:"eid"`
+	Progress  int    `json:"progress"`            // Playback position in seconds
+	UpdatedAt string `json:"updatedAt,omitempty"` // ISO Date string
+}
+
+// PlaybackProgressAPIResponse wraps the playback progress list as per the API's structure.
+type PlaybackProgressAPIResponse struct {
+	Data []PlaybackProgress `json:"data"`
+}
+
+// InProgressEpisode represents a started but unfinished episode together with its playback position.
+type InProgressEpisode struct {
+	Episode  Episode `json:"episode"`
+	PlayedAt string  `json:"playedAt"`
+	Progress int     `json:"progress"` // Playback position in seconds
+}
+
+// --- Favorite Related Types ---
+
+// FavoriteListRequest defines the request body for listing the user's favorited episodes.
+type FavoriteListRequest struct {
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// FavoriteItem represents a favorited episode.
+type FavoriteItem struct {
+	Type        string  `json:"type"`        // e.g., "EPISODE_FAVORITE"
+	FavoritedAt string  `json:"favoritedAt"` // ISO Date string
+	Episode     Episode `json:"episode"`
+}
+
+// FavoriteListResponse defines the API response structure for the favorites list.
+type FavoriteListResponse struct {
+	Data        []FavoriteItem `json:"data"`
+	LoadMoreKey interface{}    `json:"loadMoreKey,omitempty"`
+}
+
+// FavoriteUpdateRequest defines the request body for favoriting or unfavoriting an episode.
+type FavoriteUpdateRequest struct {
+	EID       string `json:"eid"`
+	Favorited bool   `json:"favorited"`
+}
+
+// --- Inbox Related Types ---
+
+// InboxListRequest defines the request body for listing the inbox feed.
+type InboxListRequest struct {
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// InboxListResponse defines the API response structure for the inbox feed,
+// which holds new episodes from the user's subscribed podcasts, newest first.
+type InboxListResponse struct {
+	Data        []Episode   `json:"data"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"`
+}
+
+// --- Top List Related Types ---
+
+// Top list categories accepted by the /v1/top-list/get API.
+const (
+	TopListHotEpisodes    = "HOT_EPISODES_IN_24_HOURS"
+	TopListRisingPodcasts = "SKYROCKET_PODCASTS"
+	TopListNewPodcasts    = "NEW_STAR_PODCASTS"
+	TopListCategory       = "CATEGORY_TOP_PODCASTS"
+)
+
+// TopListRequest defines the request body for fetching a ranking list.
+type TopListRequest struct {
+	Category   string `json:"category"`             // One of the TopList* constants
+	CategoryID string `json:"categoryId,omitempty"` // Required for TopListCategory
+}
+
+// TopListEntry represents one ranked item. Depending on the list, either Episode or Podcast is set.
+type TopListEntry struct {
+	Rank    int             `json:"rank"`
+	Trend   string          `json:"trend,omitempty"` // e.g., "UP", "DOWN", "NEW"
+	Episode *Episode        `json:"episode,omitempty"`
+	Podcast *PodcastSummary `json:"podcast,omitempty"`
+}
+
+// TopListData represents a ranking list and the date it was last updated.
+type TopListData struct {
+	Title       string         `json:"title"`
+	Category    string         `json:"category"`
+	PublishDate string         `json:"publishDate"` // ISO Date string of the ranking update
+	Items       []TopListEntry `json:"items"`
+}
+
+// TopListAPIResponse wraps the TopListData as per the API's structure.
+type TopListAPIResponse struct {
+	Data TopListData `json:"data"`
+}
+
+// --- Category and Discovery Related Types ---
+
+// Category represents a node in the podcast category tree.
+type Category struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	Children []Category `json:"children,omitempty"`
+}
+
+// CategoryListAPIResponse wraps the category tree as per the API's structure.
+type CategoryListAPIResponse struct {
+	Data []Category `json:"data"`
+}
+
+// CategoryPodcastListRequest defines the request body for listing podcasts within a category.
+type CategoryPodcastListRequest struct {
+	CategoryID  string      `json:"categoryId"`
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// CategoryPodcastListResponse defines the API response structure for podcasts within a category.
+type CategoryPodcastListResponse struct {
+	Data        []PodcastSummary `json:"data"`
+	LoadMoreKey interface{}      `json:"loadMoreKey,omitempty"`
+}
+
+// DiscoveryFeedRequest defines the request body for the personalized discovery feed.
+type DiscoveryFeedRequest struct {
+	ReturnAll   bool        `json:"returnAll"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// DiscoveryFeedItem represents one recommendation in the discovery feed.
+// Depending on Type, either Episode or Podcast is set.
+type DiscoveryFeedItem struct {
+	Type           string          `json:"type"` // e.g., "EPISODE_RECOMMEND", "PODCAST_RECOMMEND"
+	Title          string          `json:"title,omitempty"`
+	Recommendation string          `json:"recommendation,omitempty"` // Editorial reason shown in the app
+	Episode        *Episode        `json:"episode,omitempty"`
+	Podcast        *PodcastSummary `json:"podcast,omitempty"`
+}
+
+// DiscoveryFeedResponse defines the API response structure for the discovery feed.
+type DiscoveryFeedResponse struct {
+	Data        []DiscoveryFeedItem `json:"data"`
+	LoadMoreKey interface{}         `json:"loadMoreKey,omitempty"`
+}
+
+// --- User Relation Related Types ---
+
+// User relation actions accepted by the /v1/user-relation/update API.
+const (
+	RelationActionFollow   = "FOLLOW"
+	RelationActionUnfollow = "UNFOLLOW"
+)
+
+// RelationListRequest defines the request body for listing a user's followers or followings.
+type RelationListRequest struct {
+	UID         string      `json:"uid"`
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// RelationUser represents a user in a follower or following list.
+type RelationUser struct {
+	Type              string `json:"type"` // e.g., "USER"
+	UID               string `json:"uid"`
+	Avatar            Avatar `json:"avatar"`
+	Nickname          string `json:"nickname"`
+	Bio               string `json:"bio"`
+	Gender            string `json:"gender,omitempty"`
+	IsCancelled       bool   `json:"isCancelled"`
+	IPLoc             string `json:"ipLoc"`
+	Relation          string `json:"relation"` // Relation to the viewer, e.g., "STRANGE", "FOLLOWING", "FOLLOWED", "MUTUAL"
+	IsBlockedByViewer bool   `json:"isBlockedByViewer"`
+}
+
+// RelationListResponse defines the API response structure for follower and following lists.
+type RelationListResponse struct {
+	Data        []RelationUser `json:"data"`
+	LoadMoreKey interface{}    `json:"loadMoreKey,omitempty"`
+}
+
+// RelationUpdateRequest defines the request body for following or unfollowing a user.
+type RelationUpdateRequest struct {
+	UID    string `json:"uid"`
+	Action string `json:"action"` // RelationActionFollow or RelationActionUnfollow
+}
+
+// SearchSuggestionRequest defines the request body for keyword autocomplete.
+type SearchSuggestionRequest struct {
+	Keyword string `json:"keyword"`
+}
+
+// SearchSuggestion represents one autocomplete entry. Type tells whether it
+// completes to a plain keyword or points at a specific podcast or user.
+type SearchSuggestion struct {
+	Type string `json:"type"` // e.g., "KEYWORD", "PODCAST", "USER"
+	Text string `json:"text"`
+	ID   string `json:"id,omitempty"` // PID or UID when Type is not "KEYWORD"
+}
+
+// SearchSuggestionAPIResponse wraps the autocomplete suggestions as per the API's structure.
+type SearchSuggestionAPIResponse struct {
+	Data []SearchSuggestion `json:"data"`
+}
+
+// HotSearchWord represents a trending search term.
+type HotSearchWord struct {
+	Text  string `json:"text"`
+	Label string `json:"label,omitempty"` // e.g., "HOT", "NEW"
+}
+
+// HotSearchAPIResponse wraps the trending search terms as per the API's structure.
+type HotSearchAPIResponse struct {
+	Data []HotSearchWord `json:"data"`
+}
+
+// SearchPreset represents a preset query suggested in the app's empty search box.
+type SearchPreset struct {
+	Text    string `json:"text"`
+	Keyword string `json:"keyword,omitempty"` // Query to run when it differs from Text
+}
+
+// SearchPresetAPIResponse wraps the preset queries as per the API's structure.
+type SearchPresetAPIResponse struct {
+	Data []SearchPreset `json:"data"`
+}
+
+// --- Clap and Pick Related Types ---
+
+// ClapRequest defines the request body for clapping (liking) an episode.
+type ClapRequest struct {
+	EID   string `json:"eid"`
+	Count int    `json:"count"`
+}
+
+// PickCreateRequest defines the request body for picking (精选) an episode with a recommendation.
+type PickCreateRequest struct {
+	EID      string `json:"eid"`
+	PickText string `json:"pickText"`
+}
+
+// PickRemoveRequest defines the request body for removing a pick.
+type PickRemoveRequest struct {
+	EID string `json:"eid"`
+}
+
+// Pick represents an episode a user picked, together with their recommendation text.
+type Pick struct {
+	ID        string  `json:"id"`
+	PickText  string  `json:"pickText"`
+	LikeCount int     `json:"likeCount"`
+	CreatedAt string  `json:"createdAt"` // ISO Date string
+	Episode   Episode `json:"episode"`
+}
+
+// PickListRequest defines the request body for listing a user's public picks.
+type PickListRequest struct {
+	UID         string      `json:"uid"`
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// PickListResponse defines the API response structure for a user's picks.
+type PickListResponse struct {
+	Data        []Pick      `json:"data"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"`
+}
+
+// --- Topic Related Types ---
+
+// Topic represents a topic (圈子), the community space attached to podcasts with HasTopic.
+type Topic struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Brief           string   `json:"brief"`
+	Image           Picture  `json:"image"`
+	PID             string   `json:"pid,omitempty"` // Podcast the topic belongs to, if any
+	PostCount       int      `json:"postCount"`
+	SubscriberCount int      `json:"subscriberCount"`
+	Labels          []string `json:"labels,omitempty"`
+}
+
+// TopicAPIResponse wraps the Topic as per the API's structure.
+type TopicAPIResponse struct {
+	Data Topic `json:"data"`
+}
+
+// TopicPostListRequest defines the request body for listing posts in a topic.
+type TopicPostListRequest struct {
+	TopicID     string      `json:"topicId"`
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// TopicPost represents a post in a topic's feed.
+type TopicPost struct {
+	ID           string        `json:"id"`
+	Content      string        `json:"content"`
+	CreatedAt    string        `json:"createdAt"` // ISO Date string
+	User         PodcastAuthor `json:"user"`      // Reusing PodcastAuthor
+	LikeCount    int           `json:"likeCount"`
+	CommentCount int           `json:"commentCount"`
+	Pictures     []Picture     `json:"pictures,omitempty"`
+	Episode      *Episode      `json:"episode,omitempty"` // Episode the post refers to, if any
+}
+
+// TopicPostListResponse defines the API response structure for a topic's posts.
+type TopicPostListResponse struct {
+	Data        []TopicPost `json:"data"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"`
+}
+
+// --- Paid Content Related Types ---
+
+// Purchased item types returned by the /v1/purchase/list API.
+const (
+	PurchasedTypePodcast = "PODCAST"
+	PurchasedTypeEpisode = "EPISODE"
+)
+
+// PurchasedListRequest defines the request body for listing the user's purchased content.
+type PurchasedListRequest struct {
+	Limit       int         `json:"limit,omitempty"`
+	LoadMoreKey interface{} `json:"loadMoreKey,omitempty"` // Opaque key from the previous page
+}
+
+// PurchasedItem represents a paid podcast or episode the user has bought.
+// Depending on Type, either Podcast or Episode is set.
+type PurchasedItem struct {
+	Type        string          `json:"type"`        // PurchasedTypePodcast or PurchasedTypeEpisode
+	PurchasedAt string          `json:"purchasedAt"` // ISO Date string
+	Podcast     *PodcastSummary `json:"podcast,omitempty"`
+	Episode     *Episode        `json:"episode,omitempty"`
+}
+
+// PurchasedListResponse defines the API response structure for the user's purchased content.
+type PurchasedListResponse struct {
+	Data        []PurchasedItem `json:"data"`
+	LoadMoreKey interface{}     `json:"loadMoreKey,omitempty"`
+}
+
+// --- Private Media Related Types ---
+
+// PrivateMediaRequest defines the request body for exchanging a MediaKey for a signed media URL.
+type PrivateMediaRequest struct {
+	EID      string `json:"eid"`
+	MediaKey string `json:"mediaKey"`
+}
+
+// PrivateMedia is a signed, time-limited playable URL for private or paid media.
+type PrivateMedia struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mimeType,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	ExpireAt string `json:"expireAt,omitempty"` // ISO Date string after which URL stops working
+}
+
+// PrivateMediaAPIResponse wraps the PrivateMedia as per the API's structure.
+type PrivateMediaAPIResponse struct {
+	Data PrivateMedia `json:"data"`
+}